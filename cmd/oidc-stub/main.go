@@ -0,0 +1,35 @@
+// oidc-stub — локальный OIDC-провайдер для проверки входа через OIDC без внешних зависимостей.
+// Авторизация подтверждается автоматически для одного настроенного пользователя,
+// поддерживается только authorization code + PKCE (S256) и ID token с RS256.
+package main
+
+import (
+	"crypto_analyzer-api_gateway/internal/oidcstub"
+	"flag"
+	"log"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9000", "issuer URL")
+	clientID := flag.String("client-id", "api-gateway", "allowed client_id")
+	subject := flag.String("sub", "stub-user-1", "subject of the signed-in user")
+	email := flag.String("email", "stub@example.com", "email of the signed-in user")
+	username := flag.String("username", "stub", "preferred_username of the signed-in user")
+	flag.Parse()
+
+	stub, err := oidcstub.New(oidcstub.Config{
+		Issuer:   *issuer,
+		ClientID: *clientID,
+		Subject:  *subject,
+		Email:    *email,
+		Username: *username,
+	})
+	if err != nil {
+		log.Fatalf("failed to start oidc stub: %v", err)
+	}
+
+	log.Printf("oidc stub listening on %s, issuer %s", *addr, *issuer)
+	log.Fatal(http.ListenAndServe(*addr, stub))
+}
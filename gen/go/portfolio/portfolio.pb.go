@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
@@ -23,6 +24,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TransactionType int32
+
+const (
+	TransactionType_TRANSACTION_TYPE_UNSPECIFIED  TransactionType = 0
+	TransactionType_TRANSACTION_TYPE_BUY          TransactionType = 1
+	TransactionType_TRANSACTION_TYPE_SELL         TransactionType = 2
+	TransactionType_TRANSACTION_TYPE_TRANSFER_IN  TransactionType = 3
+	TransactionType_TRANSACTION_TYPE_TRANSFER_OUT TransactionType = 4
+)
+
+// Enum value maps for TransactionType.
+var (
+	TransactionType_name = map[int32]string{
+		0: "TRANSACTION_TYPE_UNSPECIFIED",
+		1: "TRANSACTION_TYPE_BUY",
+		2: "TRANSACTION_TYPE_SELL",
+		3: "TRANSACTION_TYPE_TRANSFER_IN",
+		4: "TRANSACTION_TYPE_TRANSFER_OUT",
+	}
+	TransactionType_value = map[string]int32{
+		"TRANSACTION_TYPE_UNSPECIFIED":  0,
+		"TRANSACTION_TYPE_BUY":          1,
+		"TRANSACTION_TYPE_SELL":         2,
+		"TRANSACTION_TYPE_TRANSFER_IN":  3,
+		"TRANSACTION_TYPE_TRANSFER_OUT": 4,
+	}
+)
+
+func (x TransactionType) Enum() *TransactionType {
+	p := new(TransactionType)
+	*p = x
+	return p
+}
+
+func (x TransactionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_portfolio_portfolio_proto_enumTypes[0].Descriptor()
+}
+
+func (TransactionType) Type() protoreflect.EnumType {
+	return &file_portfolio_portfolio_proto_enumTypes[0]
+}
+
+func (x TransactionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionType.Descriptor instead.
+func (TransactionType) EnumDescriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{0}
+}
+
 type CreateNewPortfolioRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -135,6 +191,171 @@ func (x *CreateNewPortfolioResponse) GetIsPublic() *wrapperspb.BoolValue {
 	return nil
 }
 
+// Незаданные поля не меняются
+type UpdatePortfolioRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsPublic      *wrapperspb.BoolValue   `protobuf:"bytes,3,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePortfolioRequest) Reset() {
+	*x = UpdatePortfolioRequest{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePortfolioRequest) ProtoMessage() {}
+
+func (x *UpdatePortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePortfolioRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpdatePortfolioRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdatePortfolioRequest) GetName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *UpdatePortfolioRequest) GetIsPublic() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.IsPublic
+	}
+	return nil
+}
+
+type UpdatePortfolioResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsPublic      *wrapperspb.BoolValue  `protobuf:"bytes,3,opt,name=is_public,json=isPublic,proto3" json:"is_public,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePortfolioResponse) Reset() {
+	*x = UpdatePortfolioResponse{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePortfolioResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePortfolioResponse) ProtoMessage() {}
+
+func (x *UpdatePortfolioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePortfolioResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePortfolioResponse) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdatePortfolioResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdatePortfolioResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePortfolioResponse) GetIsPublic() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.IsPublic
+	}
+	return nil
+}
+
+type DeletePortfolioRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePortfolioRequest) Reset() {
+	*x = DeletePortfolioRequest{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePortfolioRequest) ProtoMessage() {}
+
+func (x *DeletePortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePortfolioRequest.ProtoReflect.Descriptor instead.
+func (*DeletePortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeletePortfolioRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type GetPortfolioContentByIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -144,7 +365,7 @@ type GetPortfolioContentByIdRequest struct {
 
 func (x *GetPortfolioContentByIdRequest) Reset() {
 	*x = GetPortfolioContentByIdRequest{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[2]
+	mi := &file_portfolio_portfolio_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -156,7 +377,7 @@ func (x *GetPortfolioContentByIdRequest) String() string {
 func (*GetPortfolioContentByIdRequest) ProtoMessage() {}
 
 func (x *GetPortfolioContentByIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[2]
+	mi := &file_portfolio_portfolio_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +390,7 @@ func (x *GetPortfolioContentByIdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioContentByIdRequest.ProtoReflect.Descriptor instead.
 func (*GetPortfolioContentByIdRequest) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{2}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetPortfolioContentByIdRequest) GetId() int32 {
@@ -188,7 +409,7 @@ type GetPortfolioContentByIdResponse struct {
 
 func (x *GetPortfolioContentByIdResponse) Reset() {
 	*x = GetPortfolioContentByIdResponse{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[3]
+	mi := &file_portfolio_portfolio_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -200,7 +421,7 @@ func (x *GetPortfolioContentByIdResponse) String() string {
 func (*GetPortfolioContentByIdResponse) ProtoMessage() {}
 
 func (x *GetPortfolioContentByIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[3]
+	mi := &file_portfolio_portfolio_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -213,7 +434,7 @@ func (x *GetPortfolioContentByIdResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioContentByIdResponse.ProtoReflect.Descriptor instead.
 func (*GetPortfolioContentByIdResponse) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{3}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetPortfolioContentByIdResponse) GetAssets() map[string]float64 {
@@ -234,7 +455,7 @@ type UpsertAssetRequest struct {
 
 func (x *UpsertAssetRequest) Reset() {
 	*x = UpsertAssetRequest{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[4]
+	mi := &file_portfolio_portfolio_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -246,7 +467,7 @@ func (x *UpsertAssetRequest) String() string {
 func (*UpsertAssetRequest) ProtoMessage() {}
 
 func (x *UpsertAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[4]
+	mi := &file_portfolio_portfolio_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +480,7 @@ func (x *UpsertAssetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpsertAssetRequest.ProtoReflect.Descriptor instead.
 func (*UpsertAssetRequest) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{4}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpsertAssetRequest) GetPortfolioId() int32 {
@@ -293,7 +514,7 @@ type DeleteAssetRequest struct {
 
 func (x *DeleteAssetRequest) Reset() {
 	*x = DeleteAssetRequest{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[5]
+	mi := &file_portfolio_portfolio_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -305,7 +526,7 @@ func (x *DeleteAssetRequest) String() string {
 func (*DeleteAssetRequest) ProtoMessage() {}
 
 func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[5]
+	mi := &file_portfolio_portfolio_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,7 +539,7 @@ func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteAssetRequest.ProtoReflect.Descriptor instead.
 func (*DeleteAssetRequest) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{5}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteAssetRequest) GetPortfolioId() int32 {
@@ -346,7 +567,7 @@ type AllUserPortfolio struct {
 
 func (x *AllUserPortfolio) Reset() {
 	*x = AllUserPortfolio{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[6]
+	mi := &file_portfolio_portfolio_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +579,7 @@ func (x *AllUserPortfolio) String() string {
 func (*AllUserPortfolio) ProtoMessage() {}
 
 func (x *AllUserPortfolio) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[6]
+	mi := &file_portfolio_portfolio_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +592,7 @@ func (x *AllUserPortfolio) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllUserPortfolio.ProtoReflect.Descriptor instead.
 func (*AllUserPortfolio) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{6}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AllUserPortfolio) GetId() int32 {
@@ -404,7 +625,7 @@ type GetAllPortfoliosResponse struct {
 
 func (x *GetAllPortfoliosResponse) Reset() {
 	*x = GetAllPortfoliosResponse{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[7]
+	mi := &file_portfolio_portfolio_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -416,7 +637,7 @@ func (x *GetAllPortfoliosResponse) String() string {
 func (*GetAllPortfoliosResponse) ProtoMessage() {}
 
 func (x *GetAllPortfoliosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[7]
+	mi := &file_portfolio_portfolio_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -429,7 +650,7 @@ func (x *GetAllPortfoliosResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllPortfoliosResponse.ProtoReflect.Descriptor instead.
 func (*GetAllPortfoliosResponse) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{7}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetAllPortfoliosResponse) GetPortfolios() []*AllUserPortfolio {
@@ -439,18 +660,25 @@ func (x *GetAllPortfoliosResponse) GetPortfolios() []*AllUserPortfolio {
 	return nil
 }
 
+// Точки возвращаются по возрастанию времени в полуинтервале [from, to).
+// Пустой symbols — все активы портфеля. cursor — next_cursor предыдущего ответа.
 type GetPortfolioHistoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: Marked as deprecated in portfolio/portfolio.proto.
 	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Symbols       []string               `protobuf:"bytes,6,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetPortfolioHistoryRequest) Reset() {
 	*x = GetPortfolioHistoryRequest{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[8]
+	mi := &file_portfolio_portfolio_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -462,7 +690,7 @@ func (x *GetPortfolioHistoryRequest) String() string {
 func (*GetPortfolioHistoryRequest) ProtoMessage() {}
 
 func (x *GetPortfolioHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[8]
+	mi := &file_portfolio_portfolio_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +703,7 @@ func (x *GetPortfolioHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetPortfolioHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{8}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetPortfolioHistoryRequest) GetId() int32 {
@@ -485,6 +713,7 @@ func (x *GetPortfolioHistoryRequest) GetId() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in portfolio/portfolio.proto.
 func (x *GetPortfolioHistoryRequest) GetPage() int32 {
 	if x != nil {
 		return x.Page
@@ -499,6 +728,34 @@ func (x *GetPortfolioHistoryRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetPortfolioHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPortfolioHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPortfolioHistoryRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *GetPortfolioHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type PricePoint struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     string                 `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
@@ -509,7 +766,7 @@ type PricePoint struct {
 
 func (x *PricePoint) Reset() {
 	*x = PricePoint{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[9]
+	mi := &file_portfolio_portfolio_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -521,7 +778,7 @@ func (x *PricePoint) String() string {
 func (*PricePoint) ProtoMessage() {}
 
 func (x *PricePoint) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[9]
+	mi := &file_portfolio_portfolio_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,7 +791,7 @@ func (x *PricePoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PricePoint.ProtoReflect.Descriptor instead.
 func (*PricePoint) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{9}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PricePoint) GetTimestamp() string {
@@ -560,7 +817,7 @@ type PricePoints struct {
 
 func (x *PricePoints) Reset() {
 	*x = PricePoints{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[10]
+	mi := &file_portfolio_portfolio_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -572,7 +829,7 @@ func (x *PricePoints) String() string {
 func (*PricePoints) ProtoMessage() {}
 
 func (x *PricePoints) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[10]
+	mi := &file_portfolio_portfolio_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +842,7 @@ func (x *PricePoints) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PricePoints.ProtoReflect.Descriptor instead.
 func (*PricePoints) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{10}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PricePoints) GetPoints() []*PricePoint {
@@ -596,15 +853,17 @@ func (x *PricePoints) GetPoints() []*PricePoint {
 }
 
 type GetPortfolioHistoryResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	History       map[string]*PricePoints `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state   protoimpl.MessageState  `protogen:"open.v1"`
+	History map[string]*PricePoints `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Пустой, если точек в интервале больше нет
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetPortfolioHistoryResponse) Reset() {
 	*x = GetPortfolioHistoryResponse{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[11]
+	mi := &file_portfolio_portfolio_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -616,7 +875,7 @@ func (x *GetPortfolioHistoryResponse) String() string {
 func (*GetPortfolioHistoryResponse) ProtoMessage() {}
 
 func (x *GetPortfolioHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[11]
+	mi := &file_portfolio_portfolio_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +888,7 @@ func (x *GetPortfolioHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetPortfolioHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{11}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetPortfolioHistoryResponse) GetHistory() map[string]*PricePoints {
@@ -639,6 +898,13 @@ func (x *GetPortfolioHistoryResponse) GetHistory() map[string]*PricePoints {
 	return nil
 }
 
+func (x *GetPortfolioHistoryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type GetPublicPortfoliosRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -648,7 +914,7 @@ type GetPublicPortfoliosRequest struct {
 
 func (x *GetPublicPortfoliosRequest) Reset() {
 	*x = GetPublicPortfoliosRequest{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[12]
+	mi := &file_portfolio_portfolio_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -660,7 +926,7 @@ func (x *GetPublicPortfoliosRequest) String() string {
 func (*GetPublicPortfoliosRequest) ProtoMessage() {}
 
 func (x *GetPublicPortfoliosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[12]
+	mi := &file_portfolio_portfolio_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +939,7 @@ func (x *GetPublicPortfoliosRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPublicPortfoliosRequest.ProtoReflect.Descriptor instead.
 func (*GetPublicPortfoliosRequest) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{12}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetPublicPortfoliosRequest) GetUserId() int32 {
@@ -694,7 +960,7 @@ type PublicPortfolio struct {
 
 func (x *PublicPortfolio) Reset() {
 	*x = PublicPortfolio{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[13]
+	mi := &file_portfolio_portfolio_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -706,7 +972,7 @@ func (x *PublicPortfolio) String() string {
 func (*PublicPortfolio) ProtoMessage() {}
 
 func (x *PublicPortfolio) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[13]
+	mi := &file_portfolio_portfolio_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +985,7 @@ func (x *PublicPortfolio) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicPortfolio.ProtoReflect.Descriptor instead.
 func (*PublicPortfolio) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{13}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PublicPortfolio) GetPortfolioId() int32 {
@@ -752,7 +1018,7 @@ type GetPublicPortfoliosResponse struct {
 
 func (x *GetPublicPortfoliosResponse) Reset() {
 	*x = GetPublicPortfoliosResponse{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[14]
+	mi := &file_portfolio_portfolio_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -764,7 +1030,7 @@ func (x *GetPublicPortfoliosResponse) String() string {
 func (*GetPublicPortfoliosResponse) ProtoMessage() {}
 
 func (x *GetPublicPortfoliosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[14]
+	mi := &file_portfolio_portfolio_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -777,7 +1043,7 @@ func (x *GetPublicPortfoliosResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPublicPortfoliosResponse.ProtoReflect.Descriptor instead.
 func (*GetPublicPortfoliosResponse) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{14}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetPublicPortfoliosResponse) GetPortfolios() []*PublicPortfolio {
@@ -796,7 +1062,7 @@ type GetPortfolioProfitRequest struct {
 
 func (x *GetPortfolioProfitRequest) Reset() {
 	*x = GetPortfolioProfitRequest{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[15]
+	mi := &file_portfolio_portfolio_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -808,7 +1074,7 @@ func (x *GetPortfolioProfitRequest) String() string {
 func (*GetPortfolioProfitRequest) ProtoMessage() {}
 
 func (x *GetPortfolioProfitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[15]
+	mi := &file_portfolio_portfolio_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -821,7 +1087,7 @@ func (x *GetPortfolioProfitRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioProfitRequest.ProtoReflect.Descriptor instead.
 func (*GetPortfolioProfitRequest) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{15}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetPortfolioProfitRequest) GetId() int64 {
@@ -845,7 +1111,7 @@ type AssetProfit struct {
 
 func (x *AssetProfit) Reset() {
 	*x = AssetProfit{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[16]
+	mi := &file_portfolio_portfolio_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -857,7 +1123,7 @@ func (x *AssetProfit) String() string {
 func (*AssetProfit) ProtoMessage() {}
 
 func (x *AssetProfit) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[16]
+	mi := &file_portfolio_portfolio_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -870,7 +1136,7 @@ func (x *AssetProfit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AssetProfit.ProtoReflect.Descriptor instead.
 func (*AssetProfit) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{16}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *AssetProfit) GetSymbol() string {
@@ -924,7 +1190,7 @@ type GetPortfolioProfitResponse struct {
 
 func (x *GetPortfolioProfitResponse) Reset() {
 	*x = GetPortfolioProfitResponse{}
-	mi := &file_portfolio_portfolio_proto_msgTypes[17]
+	mi := &file_portfolio_portfolio_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -936,7 +1202,7 @@ func (x *GetPortfolioProfitResponse) String() string {
 func (*GetPortfolioProfitResponse) ProtoMessage() {}
 
 func (x *GetPortfolioProfitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_portfolio_portfolio_proto_msgTypes[17]
+	mi := &file_portfolio_portfolio_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,7 +1215,7 @@ func (x *GetPortfolioProfitResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPortfolioProfitResponse.ProtoReflect.Descriptor instead.
 func (*GetPortfolioProfitResponse) Descriptor() ([]byte, []int) {
-	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{17}
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetPortfolioProfitResponse) GetAssets() []*AssetProfit {
@@ -959,18 +1225,360 @@ func (x *GetPortfolioProfitResponse) GetAssets() []*AssetProfit {
 	return nil
 }
 
+// Сделка меняет количество актива: buy и transfer_in увеличивают, sell и transfer_out уменьшают.
+// price — цена за единицу, fee — комиссия в той же валюте.
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PortfolioId   int32                  `protobuf:"varint,2,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Type          TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=portfolio.TransactionType" json:"type,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price         float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	Fee           float64                `protobuf:"fixed64,7,opt,name=fee,proto3" json:"fee,omitempty"`
+	ExecutedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	Note          string                 `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Transaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *Transaction) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+}
+
+func (x *Transaction) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Transaction) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Transaction) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type AddTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Type          TransactionType        `protobuf:"varint,2,opt,name=type,proto3,enum=portfolio.TransactionType" json:"type,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Fee           float64                `protobuf:"fixed64,6,opt,name=fee,proto3" json:"fee,omitempty"`
+	ExecutedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	Note          string                 `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTransactionRequest) Reset() {
+	*x = AddTransactionRequest{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTransactionRequest) ProtoMessage() {}
+
+func (x *AddTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTransactionRequest.ProtoReflect.Descriptor instead.
+func (*AddTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AddTransactionRequest) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *AddTransactionRequest) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+}
+
+func (x *AddTransactionRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AddTransactionRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AddTransactionRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AddTransactionRequest) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *AddTransactionRequest) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *AddTransactionRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Сделки отдаются от новых к старым. Пустой symbol — все активы.
+type ListTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PortfolioId   int32                  `protobuf:"varint,1,opt,name=portfolio_id,json=portfolioId,proto3" json:"portfolio_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTransactionsRequest) GetPortfolioId() int32 {
+	if x != nil {
+		return x.PortfolioId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Transactions []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Пустой на последней странице
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_portfolio_portfolio_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_portfolio_portfolio_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_portfolio_portfolio_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 var File_portfolio_portfolio_proto protoreflect.FileDescriptor
 
 const file_portfolio_portfolio_proto_rawDesc = "" +
 	"\n" +
-	"\x19portfolio/portfolio.proto\x12\tportfolio\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1egoogle/protobuf/wrappers.proto\"h\n" +
+	"\x19portfolio/portfolio.proto\x12\tportfolio\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/wrappers.proto\"h\n" +
 	"\x19CreateNewPortfolioRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
 	"\tis_public\x18\x02 \x01(\v2\x1a.google.protobuf.BoolValueR\bisPublic\"y\n" +
 	"\x1aCreateNewPortfolioResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x127\n" +
-	"\tis_public\x18\x03 \x01(\v2\x1a.google.protobuf.BoolValueR\bisPublic\"0\n" +
+	"\tis_public\x18\x03 \x01(\v2\x1a.google.protobuf.BoolValueR\bisPublic\"\x93\x01\n" +
+	"\x16UpdatePortfolioRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x120\n" +
+	"\x04name\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueR\x04name\x127\n" +
+	"\tis_public\x18\x03 \x01(\v2\x1a.google.protobuf.BoolValueR\bisPublic\"v\n" +
+	"\x17UpdatePortfolioResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x127\n" +
+	"\tis_public\x18\x03 \x01(\v2\x1a.google.protobuf.BoolValueR\bisPublic\"(\n" +
+	"\x16DeletePortfolioRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"0\n" +
 	"\x1eGetPortfolioContentByIdRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"\xac\x01\n" +
 	"\x1fGetPortfolioContentByIdResponse\x12N\n" +
@@ -992,19 +1600,25 @@ const file_portfolio_portfolio_proto_rawDesc = "" +
 	"\x18GetAllPortfoliosResponse\x12;\n" +
 	"\n" +
 	"portfolios\x18\x01 \x03(\v2\x1b.portfolio.AllUserPortfolioR\n" +
-	"portfolios\"]\n" +
+	"portfolios\"\xef\x01\n" +
 	"\x1aGetPortfolioHistoryRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"@\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x04page\x18\x02 \x01(\x05B\x02\x18\x01R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x18\n" +
+	"\asymbols\x18\x06 \x03(\tR\asymbols\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"@\n" +
 	"\n" +
 	"PricePoint\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\tR\ttimestamp\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x01R\x05value\"<\n" +
 	"\vPricePoints\x12-\n" +
-	"\x06points\x18\x01 \x03(\v2\x15.portfolio.PricePointR\x06points\"\xc0\x01\n" +
+	"\x06points\x18\x01 \x03(\v2\x15.portfolio.PricePointR\x06points\"\xe1\x01\n" +
 	"\x1bGetPortfolioHistoryResponse\x12M\n" +
-	"\ahistory\x18\x01 \x03(\v23.portfolio.GetPortfolioHistoryResponse.HistoryEntryR\ahistory\x1aR\n" +
+	"\ahistory\x18\x01 \x03(\v23.portfolio.GetPortfolioHistoryResponse.HistoryEntryR\ahistory\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x1aR\n" +
 	"\fHistoryEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
 	"\x05value\x18\x02 \x01(\v2\x16.portfolio.PricePointsR\x05value:\x028\x01\"5\n" +
@@ -1031,16 +1645,56 @@ const file_portfolio_portfolio_proto_rawDesc = "" +
 	"\rcurrent_value\x18\x05 \x01(\x01R\fcurrentValue\x12\x16\n" +
 	"\x06profit\x18\x06 \x01(\x01R\x06profit\"L\n" +
 	"\x1aGetPortfolioProfitResponse\x12.\n" +
-	"\x06assets\x18\x01 \x03(\v2\x16.portfolio.AssetProfitR\x06assets2\xf3\x05\n" +
+	"\x06assets\x18\x01 \x03(\v2\x16.portfolio.AssetProfitR\x06assets\"\x99\x02\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\fportfolio_id\x18\x02 \x01(\x05R\vportfolioId\x12.\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1a.portfolio.TransactionTypeR\x04type\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x10\n" +
+	"\x03fee\x18\a \x01(\x01R\x03fee\x12;\n" +
+	"\vexecuted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x12\x12\n" +
+	"\x04note\x18\t \x01(\tR\x04note\"\x93\x02\n" +
+	"\x15AddTransactionRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x12.\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1a.portfolio.TransactionTypeR\x04type\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x10\n" +
+	"\x03fee\x18\x06 \x01(\x01R\x03fee\x12;\n" +
+	"\vexecuted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x12\x12\n" +
+	"\x04note\x18\b \x01(\tR\x04note\"\x89\x01\n" +
+	"\x17ListTransactionsRequest\x12!\n" +
+	"\fportfolio_id\x18\x01 \x01(\x05R\vportfolioId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"w\n" +
+	"\x18ListTransactionsResponse\x12:\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x16.portfolio.TransactionR\ftransactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor*\xad\x01\n" +
+	"\x0fTransactionType\x12 \n" +
+	"\x1cTRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14TRANSACTION_TYPE_BUY\x10\x01\x12\x19\n" +
+	"\x15TRANSACTION_TYPE_SELL\x10\x02\x12 \n" +
+	"\x1cTRANSACTION_TYPE_TRANSFER_IN\x10\x03\x12!\n" +
+	"\x1dTRANSACTION_TYPE_TRANSFER_OUT\x10\x042\xc4\b\n" +
 	"\x10PortfolioService\x12a\n" +
-	"\x12CreateNewPortfolio\x12$.portfolio.CreateNewPortfolioRequest\x1a%.portfolio.CreateNewPortfolioResponse\x12p\n" +
+	"\x12CreateNewPortfolio\x12$.portfolio.CreateNewPortfolioRequest\x1a%.portfolio.CreateNewPortfolioResponse\x12X\n" +
+	"\x0fUpdatePortfolio\x12!.portfolio.UpdatePortfolioRequest\x1a\".portfolio.UpdatePortfolioResponse\x12L\n" +
+	"\x0fDeletePortfolio\x12!.portfolio.DeletePortfolioRequest\x1a\x16.google.protobuf.Empty\x12p\n" +
 	"\x17GetPortfolioContentById\x12).portfolio.GetPortfolioContentByIdRequest\x1a*.portfolio.GetPortfolioContentByIdResponse\x12D\n" +
 	"\vUpsertAsset\x12\x1d.portfolio.UpsertAssetRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
 	"\vDeleteAsset\x12\x1d.portfolio.DeleteAssetRequest\x1a\x16.google.protobuf.Empty\x12a\n" +
 	"\x12GetPortfolioProfit\x12$.portfolio.GetPortfolioProfitRequest\x1a%.portfolio.GetPortfolioProfitResponse\x12O\n" +
 	"\x10GetAllPortfolios\x12\x16.google.protobuf.Empty\x1a#.portfolio.GetAllPortfoliosResponse\x12d\n" +
 	"\x13GetPortfolioHistory\x12%.portfolio.GetPortfolioHistoryRequest\x1a&.portfolio.GetPortfolioHistoryResponse\x12d\n" +
-	"\x13GetPublicPortfolios\x12%.portfolio.GetPublicPortfoliosRequest\x1a&.portfolio.GetPublicPortfoliosResponseB:Z8crypto_analyzer-api_gateway/gen/go/portfolio;portfoliopbb\x06proto3"
+	"\x13GetPublicPortfolios\x12%.portfolio.GetPublicPortfoliosRequest\x1a&.portfolio.GetPublicPortfoliosResponse\x12J\n" +
+	"\x0eAddTransaction\x12 .portfolio.AddTransactionRequest\x1a\x16.portfolio.Transaction\x12[\n" +
+	"\x10ListTransactions\x12\".portfolio.ListTransactionsRequest\x1a#.portfolio.ListTransactionsResponseB:Z8crypto_analyzer-api_gateway/gen/go/portfolio;portfoliopbb\x06proto3"
 
 var (
 	file_portfolio_portfolio_proto_rawDescOnce sync.Once
@@ -1054,65 +1708,94 @@ func file_portfolio_portfolio_proto_rawDescGZIP() []byte {
 	return file_portfolio_portfolio_proto_rawDescData
 }
 
-var file_portfolio_portfolio_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_portfolio_portfolio_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_portfolio_portfolio_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_portfolio_portfolio_proto_goTypes = []any{
-	(*CreateNewPortfolioRequest)(nil),       // 0: portfolio.CreateNewPortfolioRequest
-	(*CreateNewPortfolioResponse)(nil),      // 1: portfolio.CreateNewPortfolioResponse
-	(*GetPortfolioContentByIdRequest)(nil),  // 2: portfolio.GetPortfolioContentByIdRequest
-	(*GetPortfolioContentByIdResponse)(nil), // 3: portfolio.GetPortfolioContentByIdResponse
-	(*UpsertAssetRequest)(nil),              // 4: portfolio.UpsertAssetRequest
-	(*DeleteAssetRequest)(nil),              // 5: portfolio.DeleteAssetRequest
-	(*AllUserPortfolio)(nil),                // 6: portfolio.AllUserPortfolio
-	(*GetAllPortfoliosResponse)(nil),        // 7: portfolio.GetAllPortfoliosResponse
-	(*GetPortfolioHistoryRequest)(nil),      // 8: portfolio.GetPortfolioHistoryRequest
-	(*PricePoint)(nil),                      // 9: portfolio.PricePoint
-	(*PricePoints)(nil),                     // 10: portfolio.PricePoints
-	(*GetPortfolioHistoryResponse)(nil),     // 11: portfolio.GetPortfolioHistoryResponse
-	(*GetPublicPortfoliosRequest)(nil),      // 12: portfolio.GetPublicPortfoliosRequest
-	(*PublicPortfolio)(nil),                 // 13: portfolio.PublicPortfolio
-	(*GetPublicPortfoliosResponse)(nil),     // 14: portfolio.GetPublicPortfoliosResponse
-	(*GetPortfolioProfitRequest)(nil),       // 15: portfolio.GetPortfolioProfitRequest
-	(*AssetProfit)(nil),                     // 16: portfolio.AssetProfit
-	(*GetPortfolioProfitResponse)(nil),      // 17: portfolio.GetPortfolioProfitResponse
-	nil,                                     // 18: portfolio.GetPortfolioContentByIdResponse.AssetsEntry
-	nil,                                     // 19: portfolio.GetPortfolioHistoryResponse.HistoryEntry
-	nil,                                     // 20: portfolio.PublicPortfolio.AssetsEntry
-	(*wrapperspb.BoolValue)(nil),            // 21: google.protobuf.BoolValue
-	(*emptypb.Empty)(nil),                   // 22: google.protobuf.Empty
+	(TransactionType)(0),                    // 0: portfolio.TransactionType
+	(*CreateNewPortfolioRequest)(nil),       // 1: portfolio.CreateNewPortfolioRequest
+	(*CreateNewPortfolioResponse)(nil),      // 2: portfolio.CreateNewPortfolioResponse
+	(*UpdatePortfolioRequest)(nil),          // 3: portfolio.UpdatePortfolioRequest
+	(*UpdatePortfolioResponse)(nil),         // 4: portfolio.UpdatePortfolioResponse
+	(*DeletePortfolioRequest)(nil),          // 5: portfolio.DeletePortfolioRequest
+	(*GetPortfolioContentByIdRequest)(nil),  // 6: portfolio.GetPortfolioContentByIdRequest
+	(*GetPortfolioContentByIdResponse)(nil), // 7: portfolio.GetPortfolioContentByIdResponse
+	(*UpsertAssetRequest)(nil),              // 8: portfolio.UpsertAssetRequest
+	(*DeleteAssetRequest)(nil),              // 9: portfolio.DeleteAssetRequest
+	(*AllUserPortfolio)(nil),                // 10: portfolio.AllUserPortfolio
+	(*GetAllPortfoliosResponse)(nil),        // 11: portfolio.GetAllPortfoliosResponse
+	(*GetPortfolioHistoryRequest)(nil),      // 12: portfolio.GetPortfolioHistoryRequest
+	(*PricePoint)(nil),                      // 13: portfolio.PricePoint
+	(*PricePoints)(nil),                     // 14: portfolio.PricePoints
+	(*GetPortfolioHistoryResponse)(nil),     // 15: portfolio.GetPortfolioHistoryResponse
+	(*GetPublicPortfoliosRequest)(nil),      // 16: portfolio.GetPublicPortfoliosRequest
+	(*PublicPortfolio)(nil),                 // 17: portfolio.PublicPortfolio
+	(*GetPublicPortfoliosResponse)(nil),     // 18: portfolio.GetPublicPortfoliosResponse
+	(*GetPortfolioProfitRequest)(nil),       // 19: portfolio.GetPortfolioProfitRequest
+	(*AssetProfit)(nil),                     // 20: portfolio.AssetProfit
+	(*GetPortfolioProfitResponse)(nil),      // 21: portfolio.GetPortfolioProfitResponse
+	(*Transaction)(nil),                     // 22: portfolio.Transaction
+	(*AddTransactionRequest)(nil),           // 23: portfolio.AddTransactionRequest
+	(*ListTransactionsRequest)(nil),         // 24: portfolio.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),        // 25: portfolio.ListTransactionsResponse
+	nil,                                     // 26: portfolio.GetPortfolioContentByIdResponse.AssetsEntry
+	nil,                                     // 27: portfolio.GetPortfolioHistoryResponse.HistoryEntry
+	nil,                                     // 28: portfolio.PublicPortfolio.AssetsEntry
+	(*wrapperspb.BoolValue)(nil),            // 29: google.protobuf.BoolValue
+	(*wrapperspb.StringValue)(nil),          // 30: google.protobuf.StringValue
+	(*timestamppb.Timestamp)(nil),           // 31: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                   // 32: google.protobuf.Empty
 }
 var file_portfolio_portfolio_proto_depIdxs = []int32{
-	21, // 0: portfolio.CreateNewPortfolioRequest.is_public:type_name -> google.protobuf.BoolValue
-	21, // 1: portfolio.CreateNewPortfolioResponse.is_public:type_name -> google.protobuf.BoolValue
-	18, // 2: portfolio.GetPortfolioContentByIdResponse.assets:type_name -> portfolio.GetPortfolioContentByIdResponse.AssetsEntry
-	21, // 3: portfolio.AllUserPortfolio.is_public:type_name -> google.protobuf.BoolValue
-	6,  // 4: portfolio.GetAllPortfoliosResponse.portfolios:type_name -> portfolio.AllUserPortfolio
-	9,  // 5: portfolio.PricePoints.points:type_name -> portfolio.PricePoint
-	19, // 6: portfolio.GetPortfolioHistoryResponse.history:type_name -> portfolio.GetPortfolioHistoryResponse.HistoryEntry
-	20, // 7: portfolio.PublicPortfolio.assets:type_name -> portfolio.PublicPortfolio.AssetsEntry
-	13, // 8: portfolio.GetPublicPortfoliosResponse.portfolios:type_name -> portfolio.PublicPortfolio
-	16, // 9: portfolio.GetPortfolioProfitResponse.assets:type_name -> portfolio.AssetProfit
-	10, // 10: portfolio.GetPortfolioHistoryResponse.HistoryEntry.value:type_name -> portfolio.PricePoints
-	0,  // 11: portfolio.PortfolioService.CreateNewPortfolio:input_type -> portfolio.CreateNewPortfolioRequest
-	2,  // 12: portfolio.PortfolioService.GetPortfolioContentById:input_type -> portfolio.GetPortfolioContentByIdRequest
-	4,  // 13: portfolio.PortfolioService.UpsertAsset:input_type -> portfolio.UpsertAssetRequest
-	5,  // 14: portfolio.PortfolioService.DeleteAsset:input_type -> portfolio.DeleteAssetRequest
-	15, // 15: portfolio.PortfolioService.GetPortfolioProfit:input_type -> portfolio.GetPortfolioProfitRequest
-	22, // 16: portfolio.PortfolioService.GetAllPortfolios:input_type -> google.protobuf.Empty
-	8,  // 17: portfolio.PortfolioService.GetPortfolioHistory:input_type -> portfolio.GetPortfolioHistoryRequest
-	12, // 18: portfolio.PortfolioService.GetPublicPortfolios:input_type -> portfolio.GetPublicPortfoliosRequest
-	1,  // 19: portfolio.PortfolioService.CreateNewPortfolio:output_type -> portfolio.CreateNewPortfolioResponse
-	3,  // 20: portfolio.PortfolioService.GetPortfolioContentById:output_type -> portfolio.GetPortfolioContentByIdResponse
-	22, // 21: portfolio.PortfolioService.UpsertAsset:output_type -> google.protobuf.Empty
-	22, // 22: portfolio.PortfolioService.DeleteAsset:output_type -> google.protobuf.Empty
-	17, // 23: portfolio.PortfolioService.GetPortfolioProfit:output_type -> portfolio.GetPortfolioProfitResponse
-	7,  // 24: portfolio.PortfolioService.GetAllPortfolios:output_type -> portfolio.GetAllPortfoliosResponse
-	11, // 25: portfolio.PortfolioService.GetPortfolioHistory:output_type -> portfolio.GetPortfolioHistoryResponse
-	14, // 26: portfolio.PortfolioService.GetPublicPortfolios:output_type -> portfolio.GetPublicPortfoliosResponse
-	19, // [19:27] is the sub-list for method output_type
-	11, // [11:19] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	29, // 0: portfolio.CreateNewPortfolioRequest.is_public:type_name -> google.protobuf.BoolValue
+	29, // 1: portfolio.CreateNewPortfolioResponse.is_public:type_name -> google.protobuf.BoolValue
+	30, // 2: portfolio.UpdatePortfolioRequest.name:type_name -> google.protobuf.StringValue
+	29, // 3: portfolio.UpdatePortfolioRequest.is_public:type_name -> google.protobuf.BoolValue
+	29, // 4: portfolio.UpdatePortfolioResponse.is_public:type_name -> google.protobuf.BoolValue
+	26, // 5: portfolio.GetPortfolioContentByIdResponse.assets:type_name -> portfolio.GetPortfolioContentByIdResponse.AssetsEntry
+	29, // 6: portfolio.AllUserPortfolio.is_public:type_name -> google.protobuf.BoolValue
+	10, // 7: portfolio.GetAllPortfoliosResponse.portfolios:type_name -> portfolio.AllUserPortfolio
+	31, // 8: portfolio.GetPortfolioHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	31, // 9: portfolio.GetPortfolioHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	13, // 10: portfolio.PricePoints.points:type_name -> portfolio.PricePoint
+	27, // 11: portfolio.GetPortfolioHistoryResponse.history:type_name -> portfolio.GetPortfolioHistoryResponse.HistoryEntry
+	28, // 12: portfolio.PublicPortfolio.assets:type_name -> portfolio.PublicPortfolio.AssetsEntry
+	17, // 13: portfolio.GetPublicPortfoliosResponse.portfolios:type_name -> portfolio.PublicPortfolio
+	20, // 14: portfolio.GetPortfolioProfitResponse.assets:type_name -> portfolio.AssetProfit
+	0,  // 15: portfolio.Transaction.type:type_name -> portfolio.TransactionType
+	31, // 16: portfolio.Transaction.executed_at:type_name -> google.protobuf.Timestamp
+	0,  // 17: portfolio.AddTransactionRequest.type:type_name -> portfolio.TransactionType
+	31, // 18: portfolio.AddTransactionRequest.executed_at:type_name -> google.protobuf.Timestamp
+	22, // 19: portfolio.ListTransactionsResponse.transactions:type_name -> portfolio.Transaction
+	14, // 20: portfolio.GetPortfolioHistoryResponse.HistoryEntry.value:type_name -> portfolio.PricePoints
+	1,  // 21: portfolio.PortfolioService.CreateNewPortfolio:input_type -> portfolio.CreateNewPortfolioRequest
+	3,  // 22: portfolio.PortfolioService.UpdatePortfolio:input_type -> portfolio.UpdatePortfolioRequest
+	5,  // 23: portfolio.PortfolioService.DeletePortfolio:input_type -> portfolio.DeletePortfolioRequest
+	6,  // 24: portfolio.PortfolioService.GetPortfolioContentById:input_type -> portfolio.GetPortfolioContentByIdRequest
+	8,  // 25: portfolio.PortfolioService.UpsertAsset:input_type -> portfolio.UpsertAssetRequest
+	9,  // 26: portfolio.PortfolioService.DeleteAsset:input_type -> portfolio.DeleteAssetRequest
+	19, // 27: portfolio.PortfolioService.GetPortfolioProfit:input_type -> portfolio.GetPortfolioProfitRequest
+	32, // 28: portfolio.PortfolioService.GetAllPortfolios:input_type -> google.protobuf.Empty
+	12, // 29: portfolio.PortfolioService.GetPortfolioHistory:input_type -> portfolio.GetPortfolioHistoryRequest
+	16, // 30: portfolio.PortfolioService.GetPublicPortfolios:input_type -> portfolio.GetPublicPortfoliosRequest
+	23, // 31: portfolio.PortfolioService.AddTransaction:input_type -> portfolio.AddTransactionRequest
+	24, // 32: portfolio.PortfolioService.ListTransactions:input_type -> portfolio.ListTransactionsRequest
+	2,  // 33: portfolio.PortfolioService.CreateNewPortfolio:output_type -> portfolio.CreateNewPortfolioResponse
+	4,  // 34: portfolio.PortfolioService.UpdatePortfolio:output_type -> portfolio.UpdatePortfolioResponse
+	32, // 35: portfolio.PortfolioService.DeletePortfolio:output_type -> google.protobuf.Empty
+	7,  // 36: portfolio.PortfolioService.GetPortfolioContentById:output_type -> portfolio.GetPortfolioContentByIdResponse
+	32, // 37: portfolio.PortfolioService.UpsertAsset:output_type -> google.protobuf.Empty
+	32, // 38: portfolio.PortfolioService.DeleteAsset:output_type -> google.protobuf.Empty
+	21, // 39: portfolio.PortfolioService.GetPortfolioProfit:output_type -> portfolio.GetPortfolioProfitResponse
+	11, // 40: portfolio.PortfolioService.GetAllPortfolios:output_type -> portfolio.GetAllPortfoliosResponse
+	15, // 41: portfolio.PortfolioService.GetPortfolioHistory:output_type -> portfolio.GetPortfolioHistoryResponse
+	18, // 42: portfolio.PortfolioService.GetPublicPortfolios:output_type -> portfolio.GetPublicPortfoliosResponse
+	22, // 43: portfolio.PortfolioService.AddTransaction:output_type -> portfolio.Transaction
+	25, // 44: portfolio.PortfolioService.ListTransactions:output_type -> portfolio.ListTransactionsResponse
+	33, // [33:45] is the sub-list for method output_type
+	21, // [21:33] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_portfolio_portfolio_proto_init() }
@@ -1125,13 +1808,14 @@ func file_portfolio_portfolio_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_portfolio_portfolio_proto_rawDesc), len(file_portfolio_portfolio_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   21,
+			NumEnums:      1,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_portfolio_portfolio_proto_goTypes,
 		DependencyIndexes: file_portfolio_portfolio_proto_depIdxs,
+		EnumInfos:         file_portfolio_portfolio_proto_enumTypes,
 		MessageInfos:      file_portfolio_portfolio_proto_msgTypes,
 	}.Build()
 	File_portfolio_portfolio_proto = out.File
@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PortfolioService_CreateNewPortfolio_FullMethodName      = "/portfolio.PortfolioService/CreateNewPortfolio"
+	PortfolioService_UpdatePortfolio_FullMethodName         = "/portfolio.PortfolioService/UpdatePortfolio"
+	PortfolioService_DeletePortfolio_FullMethodName         = "/portfolio.PortfolioService/DeletePortfolio"
 	PortfolioService_GetPortfolioContentById_FullMethodName = "/portfolio.PortfolioService/GetPortfolioContentById"
 	PortfolioService_UpsertAsset_FullMethodName             = "/portfolio.PortfolioService/UpsertAsset"
 	PortfolioService_DeleteAsset_FullMethodName             = "/portfolio.PortfolioService/DeleteAsset"
@@ -28,6 +30,8 @@ const (
 	PortfolioService_GetAllPortfolios_FullMethodName        = "/portfolio.PortfolioService/GetAllPortfolios"
 	PortfolioService_GetPortfolioHistory_FullMethodName     = "/portfolio.PortfolioService/GetPortfolioHistory"
 	PortfolioService_GetPublicPortfolios_FullMethodName     = "/portfolio.PortfolioService/GetPublicPortfolios"
+	PortfolioService_AddTransaction_FullMethodName          = "/portfolio.PortfolioService/AddTransaction"
+	PortfolioService_ListTransactions_FullMethodName        = "/portfolio.PortfolioService/ListTransactions"
 )
 
 // PortfolioServiceClient is the client API for PortfolioService service.
@@ -35,6 +39,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PortfolioServiceClient interface {
 	CreateNewPortfolio(ctx context.Context, in *CreateNewPortfolioRequest, opts ...grpc.CallOption) (*CreateNewPortfolioResponse, error)
+	UpdatePortfolio(ctx context.Context, in *UpdatePortfolioRequest, opts ...grpc.CallOption) (*UpdatePortfolioResponse, error)
+	DeletePortfolio(ctx context.Context, in *DeletePortfolioRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetPortfolioContentById(ctx context.Context, in *GetPortfolioContentByIdRequest, opts ...grpc.CallOption) (*GetPortfolioContentByIdResponse, error)
 	UpsertAsset(ctx context.Context, in *UpsertAssetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -42,6 +48,8 @@ type PortfolioServiceClient interface {
 	GetAllPortfolios(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetAllPortfoliosResponse, error)
 	GetPortfolioHistory(ctx context.Context, in *GetPortfolioHistoryRequest, opts ...grpc.CallOption) (*GetPortfolioHistoryResponse, error)
 	GetPublicPortfolios(ctx context.Context, in *GetPublicPortfoliosRequest, opts ...grpc.CallOption) (*GetPublicPortfoliosResponse, error)
+	AddTransaction(ctx context.Context, in *AddTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
 }
 
 type portfolioServiceClient struct {
@@ -62,6 +70,26 @@ func (c *portfolioServiceClient) CreateNewPortfolio(ctx context.Context, in *Cre
 	return out, nil
 }
 
+func (c *portfolioServiceClient) UpdatePortfolio(ctx context.Context, in *UpdatePortfolioRequest, opts ...grpc.CallOption) (*UpdatePortfolioResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePortfolioResponse)
+	err := c.cc.Invoke(ctx, PortfolioService_UpdatePortfolio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portfolioServiceClient) DeletePortfolio(ctx context.Context, in *DeletePortfolioRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PortfolioService_DeletePortfolio_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *portfolioServiceClient) GetPortfolioContentById(ctx context.Context, in *GetPortfolioContentByIdRequest, opts ...grpc.CallOption) (*GetPortfolioContentByIdResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPortfolioContentByIdResponse)
@@ -132,11 +160,33 @@ func (c *portfolioServiceClient) GetPublicPortfolios(ctx context.Context, in *Ge
 	return out, nil
 }
 
+func (c *portfolioServiceClient) AddTransaction(ctx context.Context, in *AddTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, PortfolioService_AddTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portfolioServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, PortfolioService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PortfolioServiceServer is the server API for PortfolioService service.
 // All implementations must embed UnimplementedPortfolioServiceServer
 // for forward compatibility.
 type PortfolioServiceServer interface {
 	CreateNewPortfolio(context.Context, *CreateNewPortfolioRequest) (*CreateNewPortfolioResponse, error)
+	UpdatePortfolio(context.Context, *UpdatePortfolioRequest) (*UpdatePortfolioResponse, error)
+	DeletePortfolio(context.Context, *DeletePortfolioRequest) (*emptypb.Empty, error)
 	GetPortfolioContentById(context.Context, *GetPortfolioContentByIdRequest) (*GetPortfolioContentByIdResponse, error)
 	UpsertAsset(context.Context, *UpsertAssetRequest) (*emptypb.Empty, error)
 	DeleteAsset(context.Context, *DeleteAssetRequest) (*emptypb.Empty, error)
@@ -144,6 +194,8 @@ type PortfolioServiceServer interface {
 	GetAllPortfolios(context.Context, *emptypb.Empty) (*GetAllPortfoliosResponse, error)
 	GetPortfolioHistory(context.Context, *GetPortfolioHistoryRequest) (*GetPortfolioHistoryResponse, error)
 	GetPublicPortfolios(context.Context, *GetPublicPortfoliosRequest) (*GetPublicPortfoliosResponse, error)
+	AddTransaction(context.Context, *AddTransactionRequest) (*Transaction, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
 	mustEmbedUnimplementedPortfolioServiceServer()
 }
 
@@ -157,6 +209,12 @@ type UnimplementedPortfolioServiceServer struct{}
 func (UnimplementedPortfolioServiceServer) CreateNewPortfolio(context.Context, *CreateNewPortfolioRequest) (*CreateNewPortfolioResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateNewPortfolio not implemented")
 }
+func (UnimplementedPortfolioServiceServer) UpdatePortfolio(context.Context, *UpdatePortfolioRequest) (*UpdatePortfolioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePortfolio not implemented")
+}
+func (UnimplementedPortfolioServiceServer) DeletePortfolio(context.Context, *DeletePortfolioRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePortfolio not implemented")
+}
 func (UnimplementedPortfolioServiceServer) GetPortfolioContentById(context.Context, *GetPortfolioContentByIdRequest) (*GetPortfolioContentByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioContentById not implemented")
 }
@@ -178,6 +236,12 @@ func (UnimplementedPortfolioServiceServer) GetPortfolioHistory(context.Context,
 func (UnimplementedPortfolioServiceServer) GetPublicPortfolios(context.Context, *GetPublicPortfoliosRequest) (*GetPublicPortfoliosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPublicPortfolios not implemented")
 }
+func (UnimplementedPortfolioServiceServer) AddTransaction(context.Context, *AddTransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTransaction not implemented")
+}
+func (UnimplementedPortfolioServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
 func (UnimplementedPortfolioServiceServer) mustEmbedUnimplementedPortfolioServiceServer() {}
 func (UnimplementedPortfolioServiceServer) testEmbeddedByValue()                          {}
 
@@ -217,6 +281,42 @@ func _PortfolioService_CreateNewPortfolio_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PortfolioService_UpdatePortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).UpdatePortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PortfolioService_UpdatePortfolio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).UpdatePortfolio(ctx, req.(*UpdatePortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortfolioService_DeletePortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).DeletePortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PortfolioService_DeletePortfolio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).DeletePortfolio(ctx, req.(*DeletePortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PortfolioService_GetPortfolioContentById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPortfolioContentByIdRequest)
 	if err := dec(in); err != nil {
@@ -343,6 +443,42 @@ func _PortfolioService_GetPublicPortfolios_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PortfolioService_AddTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).AddTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PortfolioService_AddTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).AddTransaction(ctx, req.(*AddTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortfolioService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PortfolioService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PortfolioService_ServiceDesc is the grpc.ServiceDesc for PortfolioService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -354,6 +490,14 @@ var PortfolioService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateNewPortfolio",
 			Handler:    _PortfolioService_CreateNewPortfolio_Handler,
 		},
+		{
+			MethodName: "UpdatePortfolio",
+			Handler:    _PortfolioService_UpdatePortfolio_Handler,
+		},
+		{
+			MethodName: "DeletePortfolio",
+			Handler:    _PortfolioService_DeletePortfolio_Handler,
+		},
 		{
 			MethodName: "GetPortfolioContentById",
 			Handler:    _PortfolioService_GetPortfolioContentById_Handler,
@@ -382,6 +526,14 @@ var PortfolioService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPublicPortfolios",
 			Handler:    _PortfolioService_GetPublicPortfolios_Handler,
 		},
+		{
+			MethodName: "AddTransaction",
+			Handler:    _PortfolioService_AddTransaction_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _PortfolioService_ListTransactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "portfolio/portfolio.proto",
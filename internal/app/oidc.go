@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/config/model"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"crypto_analyzer-api_gateway/internal/infrastructure/oidc"
+	authUsecase "crypto_analyzer-api_gateway/internal/usecase/auth"
+	"github.com/redis/go-redis/v9"
+	"net/http"
+	"time"
+)
+
+const oidcHTTPTimeout = 10 * time.Second
+
+// initOIDC загружает метаданные провайдера и собирает usecase входа через OIDC.
+func initOIDC(ctx context.Context, cfg *model.OIDCConfig, authCfg *model.AuthConfig,
+	authServiceUsecase *authUsecase.AuthServiceUsecase, redisClient *redis.Client) (*authUsecase.OIDCUsecase, error) {
+	httpClient := &http.Client{Timeout: oidcHTTPTimeout}
+
+	discoveryCtx, cancel := context.WithTimeout(ctx, oidcHTTPTimeout)
+	defer cancel()
+
+	discovery, err := oidc.Discover(discoveryCtx, httpClient, cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := jwt.NewKeySet(oidc.NewHTTPKeySource(httpClient, discovery.JWKSURI), authCfg.JWKSRefreshInterval)
+	keySet.Start(ctx)
+
+	provider := oidc.NewProvider(oidc.ProviderConfig{
+		Name:         cfg.ProviderName,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+	}, discovery, httpClient, jwt.NewVerifier(keySet, discovery.Issuer, cfg.ClientID))
+
+	return authUsecase.NewOIDCUsecase(authServiceUsecase, provider, oidc.NewStateStore(redisClient), cfg.StateTTL), nil
+}
@@ -5,15 +5,35 @@ import (
 	authpb "crypto_analyzer-api_gateway/gen/go/auth"
 	portfoliopb "crypto_analyzer-api_gateway/gen/go/portfolio"
 	"crypto_analyzer-api_gateway/internal/config"
+	"crypto_analyzer-api_gateway/internal/config/model"
+	apikeyController "crypto_analyzer-api_gateway/internal/controller/apikey"
+	authController "crypto_analyzer-api_gateway/internal/controller/auth"
 	"crypto_analyzer-api_gateway/internal/controller/middleware"
 	"crypto_analyzer-api_gateway/internal/controller/middleware/auth"
 	portfolioController "crypto_analyzer-api_gateway/internal/controller/portfolio"
+	stepupController "crypto_analyzer-api_gateway/internal/controller/stepup"
+	portfolioDomain "crypto_analyzer-api_gateway/internal/domain/portfolio"
+	apikeyInfra "crypto_analyzer-api_gateway/internal/infrastructure/apikey"
+	auditInfra "crypto_analyzer-api_gateway/internal/infrastructure/audit"
+	authInfra "crypto_analyzer-api_gateway/internal/infrastructure/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"crypto_analyzer-api_gateway/internal/infrastructure/loginguard"
 	"crypto_analyzer-api_gateway/internal/infrastructure/metrics"
 	portfolioGRPC "crypto_analyzer-api_gateway/internal/infrastructure/portfolio/grpc"
+	"crypto_analyzer-api_gateway/internal/infrastructure/profilecache"
 	"crypto_analyzer-api_gateway/internal/infrastructure/ratelimiter"
 	"crypto_analyzer-api_gateway/internal/infrastructure/redis"
+	"crypto_analyzer-api_gateway/internal/infrastructure/revocation"
+	"crypto_analyzer-api_gateway/internal/infrastructure/session"
+	"crypto_analyzer-api_gateway/internal/infrastructure/stepup"
+	"crypto_analyzer-api_gateway/internal/infrastructure/symbols"
+	"crypto_analyzer-api_gateway/internal/infrastructure/tlscreds"
+	"crypto_analyzer-api_gateway/internal/infrastructure/tokencache"
+	apikeyUsecase "crypto_analyzer-api_gateway/internal/usecase/apikey"
+	authUsecase "crypto_analyzer-api_gateway/internal/usecase/auth"
 	"crypto_analyzer-api_gateway/internal/usecase/portfolio"
+	"crypto_analyzer-api_gateway/pkg/identity"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
@@ -24,6 +44,15 @@ import (
 	logStd "log"
 )
 
+// serverNameOr возвращает явно заданное имя сервера для TLS или хост из адреса сервиса
+func serverNameOr(serverName, addr string) string {
+	if serverName != "" {
+		return serverName
+	}
+
+	return tlscreds.ServerNameFromAddr(addr)
+}
+
 func Start(ctx context.Context) error {
 	err := logger.InitLogger()
 	if err != nil {
@@ -55,7 +84,38 @@ func Start(ctx context.Context) error {
 		return "ip:" + c.IP()
 	}, 10, 5)
 
-	authConn, err := grpc.NewClient(cfg.AuthServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	identitySigner, err := identity.NewSigner(cfg.IdentityCfg.KeyID, cfg.IdentityCfg.SigningKey, cfg.IdentityCfg.TTL)
+	if err != nil {
+		log.Error("failed to init identity signer", zap.Error(err))
+		return fmt.Errorf("failed to init identity signer: %w", err)
+	}
+
+	// Личность пользователя из ctx подписывается для каждого вызова backend-сервисов
+	identityOpts := []grpc.DialOption{
+		grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor(identitySigner)),
+		grpc.WithStreamInterceptor(identity.StreamClientInterceptor(identitySigner)),
+	}
+
+	authCreds, portfolioCreds := insecure.NewCredentials(), insecure.NewCredentials()
+	if cfg.GRPCTLSCfg.Enabled {
+		tlsReloader, err := tlscreds.NewReloader(tlscreds.Files{
+			CAFile:   cfg.GRPCTLSCfg.CAFile,
+			CertFile: cfg.GRPCTLSCfg.CertFile,
+			KeyFile:  cfg.GRPCTLSCfg.KeyFile,
+		})
+		if err != nil {
+			log.Error("invalid grpc tls credentials", zap.Error(err))
+			return fmt.Errorf("invalid grpc tls credentials: %w", err)
+		}
+		tlsReloader.Start(logger.WithLogger(ctx, log), cfg.GRPCTLSCfg.ReloadInterval)
+
+		authCreds = tlsReloader.TransportCredentials(
+			serverNameOr(cfg.GRPCTLSCfg.AuthServerName, cfg.AuthServiceURL))
+		portfolioCreds = tlsReloader.TransportCredentials(
+			serverNameOr(cfg.GRPCTLSCfg.PortfolioServerName, cfg.PortfolioServiceURL))
+	}
+
+	authConn, err := grpc.NewClient(cfg.AuthServiceURL, append(identityOpts, grpc.WithTransportCredentials(authCreds))...)
 	if err != nil {
 		log.Error("failed to connect auth service", zap.Error(err))
 		return fmt.Errorf("failed to connect auth service: %w", err)
@@ -64,9 +124,78 @@ func Start(ctx context.Context) error {
 
 	authClientProto := authpb.NewAuthServiceClient(authConn)
 
-	authMiddlewareVerifier := auth.NewAuthMiddlewareVerifier(authClientProto)
+	verifyCache := tokencache.NewTokenCache(redisClient)
+	revocationList := revocation.NewRevocationList(redisClient, cfg.AuthCfg.RevocationMaxTTL)
+	sessionStore, err := session.NewSessionStore(redisClient, cfg.AuthCfg.SessionTTL, cfg.AuthCfg.SessionEncryptionKey)
+	if err != nil {
+		log.Error("failed to init session store", zap.Error(err))
+		return fmt.Errorf("failed to init session store: %w", err)
+	}
+
+	guardCfg := cfg.AuthCfg.LoginGuard
+	loginGuard := loginguard.NewLoginGuard(redisClient,
+		loginguard.Limits{
+			MaxFailures: guardCfg.AccountMaxFailures,
+			Window:      guardCfg.FailureWindow,
+			BaseLockout: guardCfg.LockoutBase,
+			MaxLockout:  guardCfg.LockoutMax,
+		},
+		loginguard.Limits{
+			MaxFailures: guardCfg.IPMaxFailures,
+			Window:      guardCfg.FailureWindow,
+			BaseLockout: guardCfg.LockoutBase,
+			MaxLockout:  guardCfg.LockoutMax,
+		},
+	)
+
+	apiKeyStore := apikeyInfra.NewAPIKeyStore(redisClient)
+	apiKeyUsecase := apikeyUsecase.NewAPIKeyUsecase(apiKeyStore)
+	apiKeyController := apikeyController.NewAPIKeyController(apiKeyUsecase)
+
+	verifierOpts := auth.VerifierOptions{
+		Revocations:    revocationList,
+		VerifyCache:    verifyCache,
+		VerifyCacheTTL: cfg.AuthCfg.VerifyCacheTTL,
+		RemoteFallback: cfg.AuthCfg.VerifyFallback,
+		CookieAuth:     cfg.CookieCfg.Enabled,
+		APIKeys:        apiKeyUsecase,
+	}
+
+	if cfg.AuthCfg.VerifyMode == model.VerifyModeLocal {
+		keySet := jwt.NewKeySet(authInfra.NewPublicKeySource(authClientProto), cfg.AuthCfg.JWKSRefreshInterval)
+		keySet.Start(logger.WithLogger(ctx, log))
+
+		verifierOpts.LocalVerifier = jwt.NewVerifier(keySet, cfg.AuthCfg.JWTIssuer, cfg.AuthCfg.JWTAudience)
+	}
 
-	portfolioConn, err := grpc.NewClient(cfg.PortfolioServiceURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authMiddlewareVerifier := auth.NewAuthMiddlewareVerifier(authClientProto, verifierOpts)
+
+	authServiceClientContracted := authInfra.NewAuthServiceClient(authClientProto)
+	authServiceUsecase := authUsecase.NewAuthServiceUsecase(authServiceClientContracted, verifyCache, revocationList,
+		sessionStore, loginGuard)
+
+	var oidcUsecase *authUsecase.OIDCUsecase
+	if cfg.OIDCCfg.Enabled {
+		oidcUsecase, err = initOIDC(logger.WithLogger(ctx, log), cfg.OIDCCfg, cfg.AuthCfg, authServiceUsecase, redisClient)
+		if err != nil {
+			log.Error("failed to init oidc provider", zap.Error(err))
+			return fmt.Errorf("failed to init oidc provider: %w", err)
+		}
+	}
+
+	authServiceController := authController.NewAuthController(authServiceUsecase, oidcUsecase, cfg.CookieCfg)
+
+	stepUpUsecase := authUsecase.NewStepUpUsecase(authServiceClientContracted, stepup.NewStepUpStore(redisClient),
+		loginGuard, cfg.AuthCfg.StepUpTTL)
+	stepUpController := stepupController.NewStepUpController(stepUpUsecase)
+	requireStepUp := auth.RequireStepUp(stepUpUsecase)
+
+	impersonation := auth.NewImpersonation(authServiceClientContracted, auditInfra.NewAuditLog(redisClient))
+	actAs := impersonation.ActAs
+	blockActAs := impersonation.BlockImpersonated
+
+	portfolioConn, err := grpc.NewClient(cfg.PortfolioServiceURL,
+		append(identityOpts, grpc.WithTransportCredentials(portfolioCreds))...)
 	if err != nil {
 		log.Error("failed to connect portfolio service", zap.Error(err))
 		return fmt.Errorf("failed to connect portfolio service: %w", err)
@@ -76,7 +205,15 @@ func Start(ctx context.Context) error {
 	portfolioServiceClientProto := portfoliopb.NewPortfolioServiceClient(portfolioConn)
 	portfolioServiceClientContracted := portfolioGRPC.NewPortfolioServiceClient(portfolioServiceClientProto)
 
-	portfolioServiceClient := portfolio.NewPortfolioServiceUsecase(portfolioServiceClientContracted)
+	symbolRegistry, err := symbols.NewRegistry()
+	if err != nil {
+		log.Error("failed to load symbol registry", zap.Error(err))
+		return fmt.Errorf("failed to load symbol registry: %w", err)
+	}
+
+	profileCache := profilecache.NewProfileCache(redisClient, cfg.AuthCfg.ProfileCacheTTL)
+	portfolioServiceClient := portfolio.NewPortfolioServiceUsecase(portfolioServiceClientContracted, symbolRegistry,
+		authServiceClientContracted, profileCache)
 	portfolioServiceController := portfolioController.NewPortfolioController(portfolioServiceClient)
 
 	app := fiber.New()
@@ -86,6 +223,7 @@ func Start(ctx context.Context) error {
 
 	app.Use(middleware.LoggerMiddleware)
 	app.Use(middleware.TraceMiddleware)
+	app.Use(middleware.RequestIDMiddleware)
 	app.Use(middleware.MetricsMiddleware)
 	app.Use(rlMw.Handler)
 
@@ -100,13 +238,88 @@ func Start(ctx context.Context) error {
 		return c.JSON(fiber.Map{"status": "ok"})
 	})
 
-	app.Post("/portfolios", authMiddlewareVerifier.AuthVerify, portfolioServiceController.CreateNewPortfolio)
-	app.Get("/portfolio/:id", authMiddlewareVerifier.AuthVerify, portfolioServiceController.GetPortfolioContentById)
-	app.Post("/portfolio/:id/asset", authMiddlewareVerifier.AuthVerify, portfolioServiceController.UpsertAsset)
-	app.Delete("/portfolio/:id/asset", authMiddlewareVerifier.AuthVerify, portfolioServiceController.DeleteAsset)
-	app.Get("/portfolios", authMiddlewareVerifier.AuthVerify, portfolioServiceController.GetAllPortfolios)
-	app.Get("/portfolio/:id/history", authMiddlewareVerifier.AuthVerify, portfolioServiceController.GetPortfolioHistory)
-	app.Get("/portfolio/public/:username", portfolioServiceController.GetPublicPortfolios)
+	app.Post("/auth/register", authServiceController.Register)
+	app.Post("/auth/login", authServiceController.Login)
+	// Refresh и logout без AuthVerify; CSRF нужен, когда refresh-токен берётся из куки
+	refreshFromCookie := authServiceController.RefreshTokenFromCookie
+	app.Post("/auth/refresh", middleware.When(refreshFromCookie, middleware.RequireCSRF), authServiceController.Refresh)
+	app.Post("/auth/logout", middleware.When(refreshFromCookie, middleware.RequireCSRF), authServiceController.Logout)
+
+	if oidcUsecase != nil {
+		app.Get("/auth/oidc/login", authServiceController.OIDCLogin)
+		app.Get("/auth/oidc/callback", authServiceController.OIDCCallback)
+	}
+
+	// Повторная регистрация TOTP при уже включённом TOTP требует подтверждения текущим кодом
+	app.Post("/auth/totp/enroll", authMiddlewareVerifier.AuthVerify, requireStepUp, middleware.CSRFMiddleware,
+		stepUpController.EnrollTOTP)
+	app.Post("/auth/totp/confirm", authMiddlewareVerifier.AuthVerify, middleware.CSRFMiddleware,
+		stepUpController.ConfirmTOTP)
+	app.Post("/auth/step-up", authMiddlewareVerifier.AuthVerify, middleware.CSRFMiddleware,
+		stepUpController.IssueStepUpToken)
+
+	app.Get("/auth/sessions", authMiddlewareVerifier.AuthVerify, actAs, authServiceController.ListSessions)
+	app.Delete("/auth/sessions", authMiddlewareVerifier.AuthVerify, actAs, blockActAs, middleware.CSRFMiddleware,
+		authServiceController.RevokeAllSessions)
+	app.Delete("/auth/sessions/:id", authMiddlewareVerifier.AuthVerify, actAs, blockActAs, middleware.CSRFMiddleware,
+		authServiceController.RevokeSession)
+
+	// Доступ только по роли admin из проверенного в Auth Service токена, без общих секретов
+	app.Post("/admin/tokens/revoke", authMiddlewareVerifier.AuthVerifyStrict, auth.RequireRoles(portfolioDomain.RoleAdmin),
+		middleware.CSRFMiddleware, authServiceController.RevokeToken)
+
+	app.Post("/api-keys", authMiddlewareVerifier.AuthVerify, actAs, blockActAs, middleware.CSRFMiddleware,
+		apiKeyController.CreateAPIKey)
+	app.Get("/api-keys", authMiddlewareVerifier.AuthVerify, actAs, apiKeyController.ListAPIKeys)
+	app.Delete("/api-keys/:id", authMiddlewareVerifier.AuthVerify, actAs, blockActAs, middleware.CSRFMiddleware,
+		apiKeyController.RevokeAPIKey)
+
+	// Портфельные маршруты принимают и JWT, и X-API-Key
+	userAuth := authMiddlewareVerifier.APIKeyOr(authMiddlewareVerifier.AuthVerify)
+	userAuthStrict := authMiddlewareVerifier.APIKeyOr(authMiddlewareVerifier.AuthVerifyStrict)
+
+	listScope := auth.RequireScopes(portfolioDomain.ScopePortfolioRead)
+	createScope := auth.Require(auth.Policy{Scopes: []string{portfolioDomain.ScopePortfolioWrite}, AllPortfolios: true})
+	readScope := auth.Require(auth.Policy{Scopes: []string{portfolioDomain.ScopePortfolioRead}, PortfolioParam: "id"})
+	writeScope := auth.Require(auth.Policy{Scopes: []string{portfolioDomain.ScopePortfolioWrite}, PortfolioParam: "id"})
+
+	// Публичный маршрут регистрируется до /portfolio/:id/...: Fiber выбирает первый подходящий маршрут,
+	// и /portfolio/public/history иначе ушёл бы в history с id "public"
+	app.Get("/portfolio/public/:username", authMiddlewareVerifier.APIKeyOr(authMiddlewareVerifier.AuthOptional),
+		portfolioServiceController.GetPublicPortfolios)
+
+	app.Post("/portfolios", userAuth, actAs, createScope, middleware.CSRFMiddleware,
+		portfolioServiceController.CreateNewPortfolio)
+	app.Get("/portfolio/:id", userAuth, actAs, readScope, portfolioServiceController.GetPortfolioContentById)
+	app.Patch("/portfolio/:id", userAuth, actAs, middleware.When(portfolioController.UpdateChangesVisibility, blockActAs),
+		writeScope, middleware.CSRFMiddleware, portfolioServiceController.UpdatePortfolio)
+	app.Delete("/portfolio/:id", userAuthStrict, actAs, blockActAs, writeScope, requireStepUp,
+		middleware.CSRFMiddleware, portfolioServiceController.DeletePortfolio)
+	app.Post("/portfolio/:id/asset", userAuth, actAs, writeScope, middleware.CSRFMiddleware,
+		portfolioServiceController.UpsertAsset)
+	app.Delete("/portfolio/:id/asset", userAuthStrict, actAs, blockActAs, writeScope, requireStepUp,
+		middleware.CSRFMiddleware, portfolioServiceController.DeleteAsset)
+	// Пакет с удалениями защищён так же, как DELETE /portfolio/:id/asset
+	batchDeletes := portfolioController.AssetBatchHasDeletes
+	app.Post("/portfolio/:id/assets\\:batch", userAuthStrict, actAs, middleware.When(batchDeletes, blockActAs),
+		writeScope, middleware.When(batchDeletes, requireStepUp), middleware.CSRFMiddleware,
+		portfolioServiceController.ApplyAssetBatch)
+	app.Get("/portfolio/:id/export", userAuth, actAs, readScope, portfolioServiceController.ExportHoldings)
+	importApplies := portfolioController.ImportApplies
+	app.Post("/portfolio/:id/import", userAuthStrict, actAs, middleware.When(importApplies, blockActAs),
+		writeScope, middleware.When(importApplies, requireStepUp), middleware.CSRFMiddleware,
+		portfolioServiceController.ImportHoldings)
+	app.Get("/portfolios", userAuth, actAs, listScope, portfolioServiceController.GetAllPortfolios)
+	app.Get("/portfolios/summary", userAuth, actAs, listScope, portfolioServiceController.GetPortfoliosSummary)
+	app.Get("/portfolio/:id/history", userAuth, actAs, readScope, portfolioServiceController.GetPortfolioHistory)
+	app.Get("/portfolio/:id/profit", userAuth, actAs, readScope, portfolioServiceController.GetPortfolioProfit)
+	// Продажа и вывод защищены так же, как DELETE /portfolio/:id/asset
+	reducesHolding := portfolioController.TransactionReduces
+	app.Post("/portfolio/:id/transactions", userAuthStrict, actAs, middleware.When(reducesHolding, blockActAs),
+		writeScope, middleware.When(reducesHolding, requireStepUp), middleware.CSRFMiddleware,
+		portfolioServiceController.AddTransaction)
+	app.Get("/portfolio/:id/transactions", userAuth, actAs, readScope, portfolioServiceController.ListTransactions)
+	app.Get("/assets/symbols", portfolioServiceController.SearchSymbols)
 
 	log.Info("Starting API Gateway", zap.String("port", cfg.Port))
 	if err := app.Listen(":" + cfg.Port); err != nil {
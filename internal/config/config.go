@@ -6,6 +6,8 @@ import (
 	"github.com/joho/godotenv"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func getEnv(key string) (string, error) {
@@ -17,6 +19,68 @@ func getEnv(key string) (string, error) {
 	return val, nil
 }
 
+func getEnvDefault(key, defaultVal string) string {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+
+	return val
+}
+
+// minSecretSize — минимальная длина ключей SESSION_ENCRYPTION_KEY и IDENTITY_SIGNING_KEY в байтах
+const minSecretSize = 32
+
+// getEnvSecret читает обязательный ключ. Ключи появились в обновлении, поэтому ошибка объясняет,
+// как их сгенерировать, чтобы существующий deployment не падал без подсказки.
+func getEnvSecret(key string) ([]byte, error) {
+	val := os.Getenv(key)
+	if len(val) < minSecretSize {
+		return nil, fmt.Errorf("env %s must be set to at least %d random bytes "+
+			"(required since sessions and identity assertions are signed; generate with: openssl rand -base64 48)",
+			key, minSecretSize)
+	}
+
+	return []byte(val), nil
+}
+
+func getEnvDuration(key, defaultVal string) (time.Duration, error) {
+	d, err := time.ParseDuration(getEnvDefault(key, defaultVal))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse env %s: %w", key, err)
+	}
+
+	return d, nil
+}
+
+func getEnvBool(key string, defaultVal bool) (bool, error) {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal, nil
+	}
+
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse env %s: %w", key, err)
+	}
+
+	return b, nil
+}
+
+func getEnvInt(key string, defaultVal int) (int, error) {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal, nil
+	}
+
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse env %s: %w", key, err)
+	}
+
+	return i, nil
+}
+
 func LoadConfig() (*model.Config, error) {
 	env := ".env"
 
@@ -71,11 +135,202 @@ func LoadConfig() (*model.Config, error) {
 		return nil, fmt.Errorf("failed to load redis config: %w", err)
 	}
 
+	cfgAuth := &model.AuthConfig{}
+
+	cfgAuth.VerifyCacheTTL, err = getEnvDuration("VERIFY_CACHE_TTL", "5m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.VerifyMode = getEnvDefault("AUTH_VERIFY_MODE", model.VerifyModeRemote)
+	if cfgAuth.VerifyMode != model.VerifyModeRemote && cfgAuth.VerifyMode != model.VerifyModeLocal {
+		return nil, fmt.Errorf("failed to load auth config: unknown AUTH_VERIFY_MODE %q", cfgAuth.VerifyMode)
+	}
+
+	cfgAuth.VerifyFallback, err = getEnvBool("AUTH_VERIFY_FALLBACK", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.JWKSRefreshInterval, err = getEnvDuration("JWKS_REFRESH_INTERVAL", "10m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.RevocationMaxTTL, err = getEnvDuration("REVOCATION_MAX_TTL", "24h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.SessionTTL, err = getEnvDuration("SESSION_TTL", "720h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.SessionEncryptionKey, err = getEnvSecret("SESSION_ENCRYPTION_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.StepUpTTL, err = getEnvDuration("STEP_UP_TTL", "5m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgAuth.ProfileCacheTTL, err = getEnvDuration("PROFILE_CACHE_TTL", "1m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load auth config: %w", err)
+	}
+
+	cfgLoginGuard := &model.LoginGuardConfig{}
+
+	cfgLoginGuard.AccountMaxFailures, err = getEnvInt("LOGIN_MAX_FAILURES", 5)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login guard config: %w", err)
+	}
+
+	cfgLoginGuard.IPMaxFailures, err = getEnvInt("LOGIN_IP_MAX_FAILURES", 20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login guard config: %w", err)
+	}
+
+	cfgLoginGuard.FailureWindow, err = getEnvDuration("LOGIN_FAILURE_WINDOW", "15m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login guard config: %w", err)
+	}
+
+	cfgLoginGuard.LockoutBase, err = getEnvDuration("LOGIN_LOCKOUT_BASE", "1m")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login guard config: %w", err)
+	}
+
+	cfgLoginGuard.LockoutMax, err = getEnvDuration("LOGIN_LOCKOUT_MAX", "1h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login guard config: %w", err)
+	}
+
+	if cfgLoginGuard.AccountMaxFailures <= 0 || cfgLoginGuard.IPMaxFailures <= 0 {
+		return nil, fmt.Errorf("failed to load login guard config: max failures must be positive")
+	}
+
+	cfgAuth.LoginGuard = cfgLoginGuard
+
+	if cfgAuth.VerifyMode == model.VerifyModeLocal {
+		cfgAuth.JWTIssuer, err = getEnv("JWT_ISSUER")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth config: %w", err)
+		}
+
+		cfgAuth.JWTAudience, err = getEnv("JWT_AUDIENCE")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load auth config: %w", err)
+		}
+	}
+
+	cfgCookie := &model.CookieConfig{}
+
+	cfgCookie.Enabled, err = getEnvBool("AUTH_COOKIE_MODE", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cookie config: %w", err)
+	}
+
+	cfgCookie.Secure, err = getEnvBool("COOKIE_SECURE", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cookie config: %w", err)
+	}
+
+	cfgCookie.Domain = os.Getenv("COOKIE_DOMAIN")
+
+	cfgCookie.SameSite = strings.ToLower(getEnvDefault("COOKIE_SAMESITE", "strict"))
+	switch cfgCookie.SameSite {
+	case "strict", "lax", "none":
+	default:
+		return nil, fmt.Errorf("failed to load cookie config: unknown COOKIE_SAMESITE %q", cfgCookie.SameSite)
+	}
+
+	cfgOIDC := &model.OIDCConfig{}
+
+	cfgOIDC.Enabled, err = getEnvBool("OIDC_ENABLED", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oidc config: %w", err)
+	}
+
+	if cfgOIDC.Enabled {
+		cfgOIDC.ProviderName = getEnvDefault("OIDC_PROVIDER", "oidc")
+
+		cfgOIDC.IssuerURL, err = getEnv("OIDC_ISSUER_URL")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load oidc config: %w", err)
+		}
+
+		cfgOIDC.ClientID, err = getEnv("OIDC_CLIENT_ID")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load oidc config: %w", err)
+		}
+
+		cfgOIDC.ClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+
+		cfgOIDC.RedirectURL, err = getEnv("OIDC_REDIRECT_URL")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load oidc config: %w", err)
+		}
+
+		cfgOIDC.Scopes = strings.Fields(getEnvDefault("OIDC_SCOPES", "openid email profile"))
+
+		cfgOIDC.StateTTL, err = getEnvDuration("OIDC_STATE_TTL", "10m")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load oidc config: %w", err)
+		}
+	}
+
+	cfgIdentity := &model.IdentityConfig{}
+
+	cfgIdentity.KeyID = getEnvDefault("IDENTITY_KEY_ID", "v1")
+
+	cfgIdentity.SigningKey, err = getEnvSecret("IDENTITY_SIGNING_KEY")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load identity config: %w", err)
+	}
+
+	cfgIdentity.TTL, err = getEnvDuration("IDENTITY_TTL", "30s")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load identity config: %w", err)
+	}
+
+	cfgGRPCTLS := &model.GRPCTLSConfig{}
+
+	cfgGRPCTLS.Enabled, err = getEnvBool("GRPC_TLS_ENABLED", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load grpc tls config: %w", err)
+	}
+
+	if cfgGRPCTLS.Enabled {
+		cfgGRPCTLS.CAFile = os.Getenv("GRPC_TLS_CA_FILE")
+		cfgGRPCTLS.CertFile = os.Getenv("GRPC_TLS_CERT_FILE")
+		cfgGRPCTLS.KeyFile = os.Getenv("GRPC_TLS_KEY_FILE")
+		cfgGRPCTLS.AuthServerName = os.Getenv("AUTH_SERVICE_TLS_SERVER_NAME")
+		cfgGRPCTLS.PortfolioServerName = os.Getenv("PORTFOLIO_SERVICE_TLS_SERVER_NAME")
+
+		if (cfgGRPCTLS.CertFile == "") != (cfgGRPCTLS.KeyFile == "") {
+			return nil, fmt.Errorf("failed to load grpc tls config: GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE must be set together")
+		}
+
+		cfgGRPCTLS.ReloadInterval, err = getEnvDuration("GRPC_TLS_RELOAD_INTERVAL", "30s")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load grpc tls config: %w", err)
+		}
+	}
+
 	return &model.Config{
 		Port:                port,
 		AuthServiceURL:      authServiceURL,
 		PortfolioServiceURL: portfolioServiceURL,
 		AlertServiceURL:     alertServiceURL,
 		RedisCfg:            cfgRedis,
+		AuthCfg:             cfgAuth,
+		CookieCfg:           cfgCookie,
+		OIDCCfg:             cfgOIDC,
+		IdentityCfg:         cfgIdentity,
+		GRPCTLSCfg:          cfgGRPCTLS,
 	}, nil
 }
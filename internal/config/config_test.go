@@ -0,0 +1,35 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetEnvSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "long enough", value: strings.Repeat("k", minSecretSize)},
+		{name: "missing", value: "", wantErr: true},
+		{name: "too short", value: strings.Repeat("k", minSecretSize-1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_SECRET", tt.value)
+
+			key, err := getEnvSecret("TEST_SECRET")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getEnvSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "openssl rand") {
+				t.Errorf("error %q does not explain how to generate the key", err)
+			}
+			if err == nil && string(key) != tt.value {
+				t.Errorf("getEnvSecret() = %q, want %q", key, tt.value)
+			}
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package model
 
+import "time"
+
 type Config struct {
 	Port                string
 	AuthServiceURL      string
 	PortfolioServiceURL string
 	AlertServiceURL     string
 	RedisCfg            *RedisConfig
+	AuthCfg             *AuthConfig
+	CookieCfg           *CookieConfig
+	OIDCCfg             *OIDCConfig
+	IdentityCfg         *IdentityConfig
+	GRPCTLSCfg          *GRPCTLSConfig
 }
 
 type RedisConfig struct {
@@ -13,3 +20,72 @@ type RedisConfig struct {
 	Password  string
 	SessionDB int
 }
+
+const (
+	VerifyModeRemote = "remote"
+	VerifyModeLocal  = "local"
+)
+
+type AuthConfig struct {
+	VerifyCacheTTL      time.Duration
+	VerifyMode          string
+	VerifyFallback      bool
+	JWTIssuer           string
+	JWTAudience         string
+	JWKSRefreshInterval time.Duration
+	RevocationMaxTTL    time.Duration
+	SessionTTL          time.Duration
+	// SessionEncryptionKey шифрует refresh-токены сессий в Redis
+	SessionEncryptionKey []byte
+	StepUpTTL            time.Duration
+	ProfileCacheTTL      time.Duration
+	LoginGuard           *LoginGuardConfig
+}
+
+// LoginGuardConfig — пороги блокировки входа после неудачных попыток.
+// Блокировка удваивается при каждом повторе, но не превышает LockoutMax.
+type LoginGuardConfig struct {
+	AccountMaxFailures int
+	IPMaxFailures      int
+	FailureWindow      time.Duration
+	LockoutBase        time.Duration
+	LockoutMax         time.Duration
+}
+
+type CookieConfig struct {
+	Enabled  bool
+	Domain   string
+	Secure   bool
+	SameSite string
+}
+
+// OIDCConfig — вход через внешний OIDC-провайдер. ClientSecret пуст для публичного клиента.
+type OIDCConfig struct {
+	Enabled      bool
+	ProviderName string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	StateTTL     time.Duration
+}
+
+// IdentityConfig — подпись личности пользователя для backend-сервисов (pkg/identity).
+type IdentityConfig struct {
+	KeyID      string
+	SigningKey []byte
+	TTL        time.Duration
+}
+
+// GRPCTLSConfig — TLS/mTLS для соединений gateway с Auth и Portfolio Service.
+// Пустые *ServerName — имя берётся из адреса сервиса.
+type GRPCTLSConfig struct {
+	Enabled             bool
+	CAFile              string
+	CertFile            string
+	KeyFile             string
+	AuthServerName      string
+	PortfolioServerName string
+	ReloadInterval      time.Duration
+}
@@ -0,0 +1,13 @@
+package apikey
+
+import (
+	"crypto_analyzer-api_gateway/internal/usecase/apikey"
+)
+
+type APIKeyController struct {
+	apiKeyUsecaseObj *apikey.APIKeyUsecase
+}
+
+func NewAPIKeyController(apiKeyUsecaseObj *apikey.APIKeyUsecase) *APIKeyController {
+	return &APIKeyController{apiKeyUsecaseObj: apiKeyUsecaseObj}
+}
@@ -0,0 +1,69 @@
+package apikey
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/apikey/dto"
+	"crypto_analyzer-api_gateway/internal/controller/apikey/mapper"
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	portfolioDTO "crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/apikey"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func (con APIKeyController) CreateAPIKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(portfolioDTO.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	var createObj dto.CreateAPIKeyObject
+	if httpErr := binding.Bind(c, &createObj); httpErr != nil {
+		log.Warn("invalid api key data", zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	key, secret, err := con.apiKeyUsecaseObj.Create(ctx, user, createObj.Name, createObj.PortfolioIds, createObj.Access)
+	if err != nil {
+		httpErr := &portfolioDTO.HTTPError{
+			Status:  fiber.StatusInternalServerError,
+			Error:   "internal_error",
+			Message: "internal server error",
+		}
+
+		switch {
+		case errors.Is(err, apikey.ErrNameRequired), errors.Is(err, apikey.ErrInvalidAccess),
+			errors.Is(err, apikey.ErrPortfolioIdsRequired), errors.Is(err, apikey.ErrInvalidPortfolioId):
+			httpErr = &portfolioDTO.HTTPError{Status: fiber.StatusBadRequest, Error: "bad_request", Message: err.Error()}
+		case errors.Is(err, apikey.ErrScopeNotGranted):
+			httpErr = &portfolioDTO.HTTPError{Status: fiber.StatusForbidden, Error: "forbidden", Message: err.Error()}
+		case errors.Is(err, apikey.ErrTooManyKeys):
+			httpErr = &portfolioDTO.HTTPError{Status: fiber.StatusConflict, Error: "conflict", Message: err.Error()}
+		default:
+			log.Error("failed to create api key", zap.String("user_id", user.Id), zap.Error(err))
+		}
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	log.Info("api key created",
+		zap.String("user_id", user.Id),
+		zap.String("api_key_id", key.Id),
+		zap.String("access", key.Access),
+	)
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreatedAPIKey{
+		APIKey: mapper.MapAPIKey(key),
+		Key:    secret,
+	})
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyObject struct {
+	Name         string  `json:"name" validate:"required,max=100"`
+	PortfolioIds []int32 `json:"portfolio_ids" validate:"required,max=50"`
+	Access       string  `json:"access" validate:"required,regex=^(read|read_write)$"`
+}
+
+type APIKey struct {
+	Id           string    `json:"id"`
+	Name         string    `json:"name"`
+	PortfolioIds []int32   `json:"portfolio_ids"`
+	Access       string    `json:"access"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CreatedAPIKey struct {
+	APIKey
+	// Key — открытый секрет, возвращается только при создании
+	Key string `json:"key"`
+}
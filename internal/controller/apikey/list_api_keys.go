@@ -0,0 +1,40 @@
+package apikey
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/apikey/mapper"
+	portfolioDTO "crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func (con APIKeyController) ListAPIKeys(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(portfolioDTO.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	keys, err := con.apiKeyUsecaseObj.List(ctx, user.Id)
+	if err != nil {
+		log.Error("failed to list api keys", zap.String("user_id", user.Id), zap.Error(err))
+		httpErr := &portfolioDTO.HTTPError{
+			Status:  fiber.StatusInternalServerError,
+			Error:   "internal_error",
+			Message: "internal server error",
+		}
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"api_keys": mapper.MapAPIKeys(keys),
+	})
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/apikey/dto"
+	"crypto_analyzer-api_gateway/internal/domain/apikey"
+)
+
+func MapAPIKey(key apikey.APIKey) dto.APIKey {
+	portfolioIds := key.PortfolioIds
+	if portfolioIds == nil {
+		portfolioIds = []int32{}
+	}
+
+	return dto.APIKey{
+		Id:           key.Id,
+		Name:         key.Name,
+		PortfolioIds: portfolioIds,
+		Access:       key.Access,
+		CreatedAt:    key.CreatedAt,
+	}
+}
+
+func MapAPIKeys(keys []apikey.APIKey) []dto.APIKey {
+	result := make([]dto.APIKey, 0, len(keys))
+
+	for _, k := range keys {
+		result = append(result, MapAPIKey(k))
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package apikey
+
+import (
+	portfolioDTO "crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func (con APIKeyController) RevokeAPIKey(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(portfolioDTO.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	keyId := c.Params("id")
+	if keyId == "" {
+		httpErr := &portfolioDTO.HTTPError{
+			Status:  fiber.StatusBadRequest,
+			Error:   "bad_request",
+			Message: "id is required",
+		}
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	deleted, err := con.apiKeyUsecaseObj.Revoke(ctx, user.Id, keyId)
+	if err != nil {
+		log.Error("failed to revoke api key",
+			zap.String("user_id", user.Id),
+			zap.String("api_key_id", keyId),
+			zap.Error(err),
+		)
+		httpErr := &portfolioDTO.HTTPError{
+			Status:  fiber.StatusInternalServerError,
+			Error:   "internal_error",
+			Message: "internal server error",
+		}
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if !deleted {
+		httpErr := &portfolioDTO.HTTPError{
+			Status:  fiber.StatusNotFound,
+			Error:   "not_found",
+			Message: "api key not found",
+		}
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	log.Info("api key revoked", zap.String("user_id", user.Id), zap.String("api_key_id", keyId))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "api key revoked successfully",
+	})
+}
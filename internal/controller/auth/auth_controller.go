@@ -1,11 +1,57 @@
 package auth
 
-import "crypto_analyzer-api_gateway/internal/usecase/auth"
+import (
+	"crypto_analyzer-api_gateway/internal/config/model"
+	authMapper "crypto_analyzer-api_gateway/internal/controller/auth/mapper"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"crypto_analyzer-api_gateway/internal/usecase/auth"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
 
 type AuthServiceController struct {
 	authUsecaseObj *auth.AuthServiceUsecase
+	// oidcUsecaseObj равен nil, если вход через OIDC не настроен
+	oidcUsecaseObj *auth.OIDCUsecase
+	cookieCfg      *model.CookieConfig
 }
 
-func NewAuthController(authUsecaseObj *auth.AuthServiceUsecase) *AuthServiceController {
-	return &AuthServiceController{authUsecaseObj: authUsecaseObj}
+func NewAuthController(authUsecaseObj *auth.AuthServiceUsecase, oidcUsecaseObj *auth.OIDCUsecase,
+	cookieCfg *model.CookieConfig) *AuthServiceController {
+	return &AuthServiceController{authUsecaseObj: authUsecaseObj, oidcUsecaseObj: oidcUsecaseObj, cookieCfg: cookieCfg}
+}
+
+const maxUserAgentLength = 256
+
+func clientInfo(c *fiber.Ctx) domain.ClientInfo {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	return domain.ClientInfo{
+		UserAgent: userAgent,
+		IP:        c.IP(),
+	}
+}
+
+// respondTokens отдаёт токены в теле ответа либо, в cookie-режиме, только в HttpOnly-куках.
+func (con AuthServiceController) respondTokens(c *fiber.Ctx, statusCode int, tokens domain.Tokens) error {
+	if !con.cookieCfg.Enabled {
+		return c.Status(statusCode).JSON(authMapper.MapTokens(tokens))
+	}
+
+	csrfToken, err := cookie.SetTokens(c, con.cookieCfg, tokens)
+	if err != nil {
+		logger.FromContext(c.UserContext()).Error("failed to set auth cookies", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "internal server error",
+		})
+	}
+
+	return c.Status(statusCode).JSON(fiber.Map{
+		"csrf_token": csrfToken,
+	})
 }
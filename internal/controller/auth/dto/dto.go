@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+type RegisterObject struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginObject struct {
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type RefreshObject struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutObject struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RevokeTokenObject struct {
+	Token     string `json:"token"`
+	TokenID   string `json:"jti"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type Session struct {
+	Id         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	portfolioDTO "crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+	"strconv"
+)
+
+func (con AuthServiceController) Login(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	var loginObj dto.LoginObject
+	if err := c.BodyParser(&loginObj); err != nil {
+		log.Warn("failed to parse login data", zap.Error(err))
+		httpErr := badRequest("wrong login data")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if httpErr := validateLogin(loginObj); httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	res, err := con.authUsecaseObj.Login(ctx, loginObj.Email, loginObj.Username, loginObj.Password, clientInfo(c))
+	if err != nil {
+		var locked *domain.LockedError
+		if errors.As(err, &locked) {
+			log.Warn("login is locked",
+				zap.String("email", loginObj.Email),
+				zap.String("username", loginObj.Username),
+				zap.Duration("retry_after", locked.RetryAfter),
+			)
+
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(portfolioDTO.HTTPError{
+				Status:  fiber.StatusTooManyRequests,
+				Error:   "too_many_attempts",
+				Message: "too many failed login attempts, try again later",
+			})
+		}
+
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to login")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "invalid credentials")
+		}
+
+		log.Warn("failed to login",
+			zap.String("email", loginObj.Email),
+			zap.String("username", loginObj.Username),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return con.respondTokens(c, fiber.StatusOK, res)
+}
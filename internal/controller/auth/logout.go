@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+func (con AuthServiceController) Logout(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	var logoutObj dto.LogoutObject
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&logoutObj); err != nil {
+			log.Warn("failed to parse logout data", zap.Error(err))
+			httpErr := badRequest("wrong logout data")
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+	}
+
+	if logoutObj.RefreshToken == "" && con.cookieCfg.Enabled {
+		logoutObj.RefreshToken = c.Cookies(cookie.RefreshTokenName)
+	}
+
+	if logoutObj.RefreshToken == "" {
+		httpErr := badRequest("refresh_token is required")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var accessToken string
+	if authHeader := c.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		accessToken = strings.TrimPrefix(authHeader, "Bearer ")
+	} else if con.cookieCfg.Enabled {
+		accessToken = c.Cookies(cookie.AccessTokenName)
+	}
+
+	err := con.authUsecaseObj.Logout(ctx, logoutObj.RefreshToken, accessToken)
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to logout")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to logout")
+		}
+
+		log.Error("failed to logout", zap.Error(err))
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if con.cookieCfg.Enabled {
+		cookie.Clear(c, con.cookieCfg)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "logged out successfully",
+	})
+}
@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+)
+
+func MapTokens(tokens auth.Tokens) dto.Tokens {
+	return dto.Tokens{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+}
+
+func MapSessions(sessions []auth.Session, currentAccessToken string) []dto.Session {
+	result := make([]dto.Session, 0, len(sessions))
+
+	currentHash := ""
+	if currentAccessToken != "" {
+		currentHash = jwt.TokenHash(currentAccessToken)
+	}
+
+	for _, s := range sessions {
+		result = append(result, dto.Session{
+			Id:         s.Id,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Current:    currentHash != "" && s.AccessTokenHash == currentHash,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	portfolioDTO "crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"net/url"
+	"strings"
+)
+
+// isLocalRedirect разрешает возврат только на относительный путь этого же сайта (защита от open redirect).
+// Управляющие символы запрещены: браузеры вырезают \t и \n, и "/\t/evil.com" превращается в "//evil.com".
+func isLocalRedirect(path string) bool {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.Contains(path, "\\") {
+		return false
+	}
+
+	for i := 0; i < len(path); i++ {
+		if path[i] < 0x20 || path[i] == 0x7f {
+			return false
+		}
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return false
+	}
+
+	return u.Scheme == "" && u.Host == "" && u.User == nil
+}
+
+func (con AuthServiceController) OIDCLogin(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	redirectAfter := c.Query("redirect")
+	if redirectAfter != "" && !isLocalRedirect(redirectAfter) {
+		httpErr := badRequest("redirect must be a relative path")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	authURL, binding, err := con.oidcUsecaseObj.Begin(ctx, redirectAfter)
+	if err != nil {
+		log.Error("failed to start oidc login", zap.Error(err))
+		return internalError(c)
+	}
+
+	cookie.SetOIDCBinding(c, con.cookieCfg, binding, con.oidcUsecaseObj.StateTTL())
+
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+func (con AuthServiceController) OIDCCallback(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	// Куки привязки одноразовая, как и сам state
+	binding := c.Cookies(cookie.OIDCBindingName)
+	cookie.ClearOIDCBinding(c, con.cookieCfg)
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		log.Warn("oidc provider returned error",
+			zap.String("error", providerErr),
+			zap.String("description", c.Query("error_description")),
+		)
+
+		return c.Status(fiber.StatusUnauthorized).JSON(portfolioDTO.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthenticated",
+			Message: "login was denied by identity provider",
+		})
+	}
+
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" {
+		httpErr := badRequest("state and code are required")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	tokens, redirectAfter, err := con.oidcUsecaseObj.Complete(ctx, state, binding, code, clientInfo(c))
+	if err != nil {
+		log.Warn("failed to complete oidc login", zap.Error(err))
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidOIDCState):
+			httpErr := badRequest("login session expired, start again")
+			return c.Status(httpErr.Status).JSON(httpErr)
+		case errors.Is(err, domain.ErrInvalidIDToken), errors.Is(err, domain.ErrOIDCCodeRejected):
+			return c.Status(fiber.StatusUnauthorized).JSON(portfolioDTO.HTTPError{
+				Status:  fiber.StatusUnauthorized,
+				Error:   "unauthenticated",
+				Message: "identity provider login failed",
+			})
+		}
+
+		if st, ok := status.FromError(err); ok {
+			httpErr := mapper.GrpcCodeToHTTPError(st.Code(), "failed to login")
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		return c.Status(fiber.StatusBadGateway).JSON(portfolioDTO.HTTPError{
+			Status:  fiber.StatusBadGateway,
+			Error:   "bad_gateway",
+			Message: "identity provider is unavailable",
+		})
+	}
+
+	// Браузерный сценарий: токены уходят в куки, пользователь возвращается на исходную страницу
+	if con.cookieCfg.Enabled && redirectAfter != "" {
+		if _, err := cookie.SetTokens(c, con.cookieCfg, tokens); err != nil {
+			log.Error("failed to set auth cookies", zap.Error(err))
+			return internalError(c)
+		}
+
+		return c.Redirect(redirectAfter, fiber.StatusFound)
+	}
+
+	return con.respondTokens(c, fiber.StatusOK, tokens)
+}
@@ -0,0 +1,320 @@
+package auth
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/config/model"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"crypto_analyzer-api_gateway/internal/infrastructure/oidc"
+	"crypto_analyzer-api_gateway/internal/oidcstub"
+	authUsecase "crypto_analyzer-api_gateway/internal/usecase/auth"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID    = "api-gateway"
+	testCallbackURL = "http://gateway.test/auth/oidc/callback"
+)
+
+type fakeAuthService struct {
+	domain.AuthServiceContract
+
+	mu         sync.Mutex
+	identities []domain.ExternalIdentity
+}
+
+func (f *fakeAuthService) ExchangeExternalIdentity(_ context.Context, identity domain.ExternalIdentity) (domain.Tokens, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.identities = append(f.identities, identity)
+	return domain.Tokens{AccessToken: "access-" + identity.Subject, RefreshToken: "refresh-" + identity.Subject}, nil
+}
+
+func (f *fakeAuthService) Verify(context.Context, string) (portfolio.User, error) {
+	return portfolio.User{Id: "1"}, nil
+}
+
+type fakeSessions struct {
+	domain.SessionStoreContract
+}
+
+func (fakeSessions) Save(context.Context, domain.Session) error {
+	return nil
+}
+
+type oidcTestEnv struct {
+	app         *fiber.App
+	authService *fakeAuthService
+	// provider — HTTP-клиент «браузера» к провайдеру, редиректы не выполняет
+	provider *http.Client
+}
+
+// newOIDCTestEnv поднимает oidc-stub и собирает gateway так же, как initOIDC
+func newOIDCTestEnv(t *testing.T) *oidcTestEnv {
+	t.Helper()
+
+	srv := httptest.NewUnstartedServer(nil)
+	issuer := "http://" + srv.Listener.Addr().String()
+
+	stub, err := oidcstub.New(oidcstub.Config{
+		Issuer:   issuer,
+		ClientID: testClientID,
+		Subject:  "stub-user-1",
+		Email:    "stub@example.com",
+		Username: "stub",
+	})
+	if err != nil {
+		t.Fatalf("oidcstub.New: %v", err)
+	}
+	srv.Config.Handler = stub
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	discovery, err := oidc.Discover(context.Background(), srv.Client(), issuer)
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+
+	keySet := jwt.NewKeySet(oidc.NewHTTPKeySource(srv.Client(), discovery.JWKSURI), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	keySet.Start(ctx)
+
+	provider := oidc.NewProvider(oidc.ProviderConfig{
+		Name:        "stub",
+		ClientID:    testClientID,
+		RedirectURL: testCallbackURL,
+		Scopes:      []string{"openid", "email", "profile"},
+	}, discovery, srv.Client(), jwt.NewVerifier(keySet, discovery.Issuer, testClientID))
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = redisClient.Close() })
+
+	authService := &fakeAuthService{}
+	authServiceUsecase := authUsecase.NewAuthServiceUsecase(authService, nil, nil, fakeSessions{}, nil)
+	oidcUsecase := authUsecase.NewOIDCUsecase(authServiceUsecase, provider, oidc.NewStateStore(redisClient), time.Minute)
+
+	con := NewAuthController(authServiceUsecase, oidcUsecase, &model.CookieConfig{})
+	app := fiber.New()
+	app.Get("/auth/oidc/login", con.OIDCLogin)
+	app.Get("/auth/oidc/callback", con.OIDCCallback)
+
+	browser := srv.Client()
+	browser.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	return &oidcTestEnv{app: app, authService: authService, provider: browser}
+}
+
+// login начинает вход и возвращает адрес страницы провайдера и куки привязки
+func (e *oidcTestEnv) login(t *testing.T) (*url.URL, *http.Cookie) {
+	t.Helper()
+
+	res, err := e.app.Test(httptest.NewRequest(fiber.MethodGet, "/auth/oidc/login", nil))
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if res.StatusCode != fiber.StatusFound {
+		t.Fatalf("login status = %d, want %d", res.StatusCode, fiber.StatusFound)
+	}
+
+	var binding *http.Cookie
+	for _, c := range res.Cookies() {
+		if c.Name == cookie.OIDCBindingName {
+			binding = c
+		}
+	}
+	if binding == nil || binding.Value == "" || !binding.HttpOnly {
+		t.Fatalf("login did not set an HttpOnly binding cookie: %+v", binding)
+	}
+
+	authURL, err := url.Parse(res.Header.Get(fiber.HeaderLocation))
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+
+	return authURL, binding
+}
+
+// authorize проходит страницу провайдера и возвращает адрес callback с code и state
+func (e *oidcTestEnv) authorize(t *testing.T, authURL *url.URL) *url.URL {
+	t.Helper()
+
+	res, err := e.provider.Get(authURL.String())
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want %d", res.StatusCode, http.StatusFound)
+	}
+
+	callbackURL, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("parse callback url: %v", err)
+	}
+
+	return callbackURL
+}
+
+func (e *oidcTestEnv) callback(t *testing.T, callbackURL *url.URL, binding *http.Cookie) int {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, callbackURL.RequestURI(), nil)
+	if binding != nil {
+		req.AddCookie(binding)
+	}
+
+	res, err := e.app.Test(req)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+
+	return res.StatusCode
+}
+
+func TestOIDCLoginFlow(t *testing.T) {
+	env := newOIDCTestEnv(t)
+
+	authURL, binding := env.login(t)
+	callbackURL := env.authorize(t, authURL)
+
+	if got := env.callback(t, callbackURL, binding); got != fiber.StatusOK {
+		t.Fatalf("callback status = %d, want %d", got, fiber.StatusOK)
+	}
+
+	if len(env.authService.identities) != 1 {
+		t.Fatalf("ExchangeExternalIdentity calls = %d, want 1", len(env.authService.identities))
+	}
+	identity := env.authService.identities[0]
+	if identity.Provider != "stub" || identity.Subject != "stub-user-1" || identity.Email != "stub@example.com" ||
+		!identity.EmailVerified || identity.Username != "stub" {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	// Повтор того же callback: state уже использован
+	if got := env.callback(t, callbackURL, binding); got != fiber.StatusBadRequest {
+		t.Errorf("replayed callback status = %d, want %d", got, fiber.StatusBadRequest)
+	}
+	if len(env.authService.identities) != 1 {
+		t.Errorf("replayed callback reached Auth Service")
+	}
+}
+
+func TestOIDCCallbackRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		// tamper меняет адрес страницы провайдера, callback или куки привязки
+		tamperAuthURL  func(u *url.URL)
+		tamperCallback func(u *url.URL)
+		tamperBinding  func(env *oidcTestEnv, t *testing.T, binding *http.Cookie) *http.Cookie
+		wantStatus     int
+	}{
+		{
+			name: "nonce mismatch",
+			tamperAuthURL: func(u *url.URL) {
+				q := u.Query()
+				q.Set("nonce", "attacker-nonce")
+				u.RawQuery = q.Encode()
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "code rejected by provider",
+			tamperCallback: func(u *url.URL) {
+				q := u.Query()
+				q.Set("code", "forged-code")
+				u.RawQuery = q.Encode()
+			},
+			wantStatus: fiber.StatusUnauthorized,
+		},
+		{
+			name: "unknown state",
+			tamperCallback: func(u *url.URL) {
+				q := u.Query()
+				q.Set("state", "forged-state")
+				u.RawQuery = q.Encode()
+			},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "no binding cookie",
+			tamperBinding: func(*oidcTestEnv, *testing.T, *http.Cookie) *http.Cookie {
+				return nil
+			},
+			wantStatus: fiber.StatusBadRequest,
+		},
+		{
+			name: "binding cookie of another login",
+			tamperBinding: func(env *oidcTestEnv, t *testing.T, _ *http.Cookie) *http.Cookie {
+				_, other := env.login(t)
+				return other
+			},
+			wantStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newOIDCTestEnv(t)
+
+			authURL, binding := env.login(t)
+			if tt.tamperAuthURL != nil {
+				tt.tamperAuthURL(authURL)
+			}
+
+			callbackURL := env.authorize(t, authURL)
+			if tt.tamperCallback != nil {
+				tt.tamperCallback(callbackURL)
+			}
+			if tt.tamperBinding != nil {
+				binding = tt.tamperBinding(env, t, binding)
+			}
+
+			if got := env.callback(t, callbackURL, binding); got != tt.wantStatus {
+				t.Errorf("callback status = %d, want %d", got, tt.wantStatus)
+			}
+			if len(env.authService.identities) != 0 {
+				t.Errorf("rejected login reached Auth Service: %+v", env.authService.identities)
+			}
+		})
+	}
+}
+
+func TestIsLocalRedirect(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "/", want: true},
+		{path: "/portfolio/1?tab=history#top", want: true},
+		{path: "", want: false},
+		{path: "portfolio", want: false},
+		{path: "//evil.com", want: false},
+		{path: "/\\evil.com", want: false},
+		{path: "/\t/evil.com", want: false},
+		{path: "/\n/evil.com", want: false},
+		{path: "/\x00", want: false},
+		{path: "/\x7f", want: false},
+		{path: "https://evil.com/", want: false},
+		{path: "javascript:alert(1)", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isLocalRedirect(tt.path); got != tt.want {
+			t.Errorf("isLocalRedirect(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con AuthServiceController) Refresh(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	var refreshObj dto.RefreshObject
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&refreshObj); err != nil {
+			log.Warn("failed to parse refresh data", zap.Error(err))
+			httpErr := badRequest("wrong refresh data")
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+	}
+
+	if refreshObj.RefreshToken == "" && con.cookieCfg.Enabled {
+		refreshObj.RefreshToken = c.Cookies(cookie.RefreshTokenName)
+	}
+
+	if refreshObj.RefreshToken == "" {
+		httpErr := badRequest("refresh_token is required")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	res, err := con.authUsecaseObj.Refresh(ctx, refreshObj.RefreshToken, clientInfo(c))
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to refresh token")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to refresh token")
+		}
+
+		log.Warn("failed to refresh token", zap.Error(err))
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return con.respondTokens(c, fiber.StatusOK, res)
+}
+
+// RefreshTokenFromCookie сообщает, что refresh и logout возьмут refresh-токен из куки: тела с refresh_token нет,
+// а кука есть. Такой запрос браузер может отправить с чужого сайта, поэтому он требует CSRF-токен.
+// Тело, которое не разбирается, считается запросом без refresh_token.
+func (con AuthServiceController) RefreshTokenFromCookie(c *fiber.Ctx) bool {
+	if !con.cookieCfg.Enabled || c.Cookies(cookie.RefreshTokenName) == "" {
+		return false
+	}
+
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if len(c.Body()) > 0 {
+		if err := json.Unmarshal(c.Body(), &body); err != nil {
+			return true
+		}
+	}
+
+	return body.RefreshToken == ""
+}
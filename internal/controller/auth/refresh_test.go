@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/config/model"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"github.com/gofiber/fiber/v2"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRefreshTokenFromCookie(t *testing.T) {
+	tests := []struct {
+		name          string
+		cookieMode    bool
+		refreshCookie bool
+		body          string
+		want          bool
+	}{
+		{name: "cookie and empty body", cookieMode: true, refreshCookie: true, want: true},
+		{name: "cookie and body without token", cookieMode: true, refreshCookie: true, body: `{}`, want: true},
+		{name: "cookie and empty token in body", cookieMode: true, refreshCookie: true, body: `{"refresh_token":""}`, want: true},
+		{name: "cookie and malformed body", cookieMode: true, refreshCookie: true, body: `{"refresh_token":`, want: true},
+		{name: "token in body", cookieMode: true, refreshCookie: true, body: `{"refresh_token":"r1"}`},
+		{name: "no refresh cookie", cookieMode: true, body: `{}`},
+		{name: "cookie mode disabled", refreshCookie: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			con := AuthServiceController{cookieCfg: &model.CookieConfig{Enabled: tt.cookieMode}}
+
+			var got bool
+			app := fiber.New()
+			app.Post("/auth/refresh", func(c *fiber.Ctx) error {
+				got = con.RefreshTokenFromCookie(c)
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(fiber.MethodPost, "/auth/refresh", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.refreshCookie {
+				req.Header.Set(fiber.HeaderCookie, cookie.RefreshTokenName+"=r1")
+			}
+
+			if _, err := app.Test(req); err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RefreshTokenFromCookie() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
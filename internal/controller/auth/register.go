@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con AuthServiceController) Register(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	var registerObj dto.RegisterObject
+	if err := c.BodyParser(&registerObj); err != nil {
+		log.Warn("failed to parse register data", zap.Error(err))
+		httpErr := badRequest("wrong register data")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if httpErr := validateRegister(registerObj); httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	res, err := con.authUsecaseObj.Register(ctx, registerObj.Username, registerObj.Email, registerObj.Password,
+		clientInfo(c))
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to register")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to register")
+		}
+
+		log.Warn("failed to register",
+			zap.String("username", registerObj.Username),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return con.respondTokens(c, fiber.StatusCreated, res)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"time"
+)
+
+func (con AuthServiceController) RevokeToken(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	var revokeObj dto.RevokeTokenObject
+	if err := c.BodyParser(&revokeObj); err != nil {
+		log.Warn("failed to parse revoke data", zap.Error(err))
+		httpErr := badRequest("wrong revoke data")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if (revokeObj.Token == "") == (revokeObj.TokenID == "") {
+		httpErr := badRequest("exactly one of token or jti is required")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var err error
+	if revokeObj.Token != "" {
+		err = con.authUsecaseObj.RevokeToken(ctx, revokeObj.Token)
+	} else {
+		var expiresAt time.Time
+		if revokeObj.ExpiresAt > 0 {
+			expiresAt = time.Unix(revokeObj.ExpiresAt, 0)
+		}
+		err = con.authUsecaseObj.RevokeTokenID(ctx, revokeObj.TokenID, expiresAt)
+	}
+	if err != nil {
+		log.Error("failed to revoke token", zap.String("jti", revokeObj.TokenID), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "internal server error",
+		})
+	}
+
+	log.Info("token revoked by admin", zap.String("jti", revokeObj.TokenID))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "token revoked successfully",
+	})
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	authMapper "crypto_analyzer-api_gateway/internal/controller/auth/mapper"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func internalError(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusInternalServerError).JSON(dto.HTTPError{
+		Status:  fiber.StatusInternalServerError,
+		Error:   "internal_error",
+		Message: "internal server error",
+	})
+}
+
+func (con AuthServiceController) ListSessions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	sessions, err := con.authUsecaseObj.ListSessions(ctx, user.Id)
+	if err != nil {
+		log.Error("failed to list sessions", zap.String("user_id", user.Id), zap.Error(err))
+		return internalError(c)
+	}
+
+	currentToken, _ := c.Locals(cookie.AccessTokenLocal).(string)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"sessions": authMapper.MapSessions(sessions, currentToken),
+	})
+}
+
+func (con AuthServiceController) RevokeSession(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	sessionId := c.Params("id")
+	if sessionId == "" {
+		httpErr := badRequest("id is required")
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	found, err := con.authUsecaseObj.RevokeSession(ctx, user.Id, sessionId)
+	if err != nil {
+		log.Error("failed to revoke session",
+			zap.String("user_id", user.Id),
+			zap.String("session_id", sessionId),
+			zap.Error(err),
+		)
+		return internalError(c)
+	}
+
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(dto.HTTPError{
+			Status:  fiber.StatusNotFound,
+			Error:   "not_found",
+			Message: "session not found",
+		})
+	}
+
+	log.Info("session revoked", zap.String("user_id", user.Id), zap.String("session_id", sessionId))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "session revoked successfully",
+	})
+}
+
+func (con AuthServiceController) RevokeAllSessions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	revoked, err := con.authUsecaseObj.RevokeAllSessions(ctx, user.Id)
+	if err != nil {
+		log.Error("failed to revoke sessions",
+			zap.String("user_id", user.Id),
+			zap.Int("revoked", revoked),
+			zap.Error(err),
+		)
+		// Остальные сессии уже завершены; повторный запрос доберёт оставшиеся
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.HTTPError{
+			Status:  fiber.StatusInternalServerError,
+			Error:   "internal_error",
+			Message: fmt.Sprintf("revoked %d sessions, failed to revoke the rest; retry the request", revoked),
+		})
+	}
+
+	// Текущая сессия тоже завершена — убираем её куки
+	if con.cookieCfg.Enabled {
+		cookie.Clear(c, con.cookieCfg)
+	}
+
+	log.Info("all sessions revoked", zap.String("user_id", user.Id), zap.Int("revoked", revoked))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "all sessions revoked successfully",
+		"revoked": revoked,
+	})
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/auth/dto"
+	portfolioDTO "crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"net/mail"
+	"regexp"
+)
+
+// Длина пароля считается в байтах: bcrypt в Auth Service учитывает только первые 72 байта
+const (
+	minPasswordBytes = 8
+	maxPasswordBytes = 72
+)
+
+var usernameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+func badRequest(msg string) *portfolioDTO.HTTPError {
+	return &portfolioDTO.HTTPError{
+		Status:  fiber.StatusBadRequest,
+		Error:   "bad_request",
+		Message: msg,
+	}
+}
+
+func validatePassword(password string) *portfolioDTO.HTTPError {
+	if password == "" {
+		return badRequest("password is required")
+	}
+
+	if len(password) < minPasswordBytes || len(password) > maxPasswordBytes {
+		return badRequest(fmt.Sprintf("password must be between %d and %d bytes", minPasswordBytes, maxPasswordBytes))
+	}
+
+	return nil
+}
+
+func validateEmail(email string) *portfolioDTO.HTTPError {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return badRequest("wrong email")
+	}
+
+	return nil
+}
+
+func validateUsername(username string) *portfolioDTO.HTTPError {
+	if !usernameRegexp.MatchString(username) {
+		return badRequest("username must be 3-32 characters: letters, digits, '_', '.', '-'")
+	}
+
+	return nil
+}
+
+func validateRegister(obj dto.RegisterObject) *portfolioDTO.HTTPError {
+	if obj.Username == "" {
+		return badRequest("username is required")
+	}
+	if httpErr := validateUsername(obj.Username); httpErr != nil {
+		return httpErr
+	}
+
+	if obj.Email == "" {
+		return badRequest("email is required")
+	}
+	if httpErr := validateEmail(obj.Email); httpErr != nil {
+		return httpErr
+	}
+
+	return validatePassword(obj.Password)
+}
+
+func validateLogin(obj dto.LoginObject) *portfolioDTO.HTTPError {
+	if obj.Email == "" && obj.Username == "" {
+		return badRequest("email or username is required")
+	}
+
+	if obj.Password == "" {
+		return badRequest("password is required")
+	}
+
+	return nil
+}
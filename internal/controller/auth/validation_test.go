@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{name: "minimum length", password: "12345678"},
+		{name: "maximum length", password: strings.Repeat("a", 72)},
+		{name: "multibyte within limit", password: strings.Repeat("я", 36)},
+		{name: "empty", password: "", wantErr: "password is required"},
+		{name: "too short", password: "1234567", wantErr: "password must be between 8 and 72 bytes"},
+		{name: "too long", password: strings.Repeat("a", 73), wantErr: "password must be between 8 and 72 bytes"},
+		// 37 кириллических букв — 74 байта: bcrypt обрезал бы пароль
+		{name: "multibyte over limit", password: strings.Repeat("я", 37), wantErr: "password must be between 8 and 72 bytes"},
+		// 4 символа, но 8 байт
+		{name: "short in characters but long enough in bytes", password: "яяяя"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpErr := validatePassword(tt.password)
+			if tt.wantErr == "" {
+				if httpErr != nil {
+					t.Fatalf("validatePassword() = %q, want nil", httpErr.Message)
+				}
+				return
+			}
+
+			if httpErr == nil || httpErr.Message != tt.wantErr {
+				t.Errorf("validatePassword() = %+v, want %q", httpErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+// Package binding заполняет структуры запросов из path, query, заголовков и тела
+// по тегам и проверяет их правилами из тега validate.
+//
+//	type Request struct {
+//		Id     int    `path:"id" validate:"required,min=1"`
+//		Symbol string `query:"symbol" validate:"required,regex=^[A-Za-z0-9]+$"`
+//		Amount float64 `json:"amount" validate:"min=0"`
+//	}
+//
+// Поля без path/query/header берутся из тела. Элементы срезов структур в теле
+// проверяются теми же правилами, нарушения адресуются как "operations[2].symbol".
+// Правило regex должно идти последним: всё после "regex=" считается шаблоном, в том числе запятые.
+package binding
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	SourcePath   = "path"
+	SourceQuery  = "query"
+	SourceHeader = "header"
+	SourceBody   = "body"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// User возвращает пользователя, установленного middleware аутентификации.
+func User(c *fiber.Ctx) (*portfolio.User, *dto.HTTPError) {
+	user, ok := c.Locals("user").(*portfolio.User)
+	if !ok || user == nil {
+		return nil, &dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		}
+	}
+
+	return user, nil
+}
+
+// Bind заполняет dst (указатель на структуру) и проверяет все поля.
+// Нарушения собираются целиком и возвращаются одной ошибкой 400.
+func Bind(c *fiber.Ctx, dst any) *dto.HTTPError {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("binding: dst must be a pointer to struct, got %T", dst))
+	}
+	v = v.Elem()
+
+	fields, err := fieldsOf(v.Type())
+	if err != nil {
+		panic(err)
+	}
+
+	var violations []dto.FieldError
+
+	if hasBodyFields(fields) && len(c.Body()) > 0 {
+		if err := c.BodyParser(dst); err != nil {
+			return badRequest([]dto.FieldError{{
+				Field:   "body",
+				Source:  SourceBody,
+				Message: "malformed request body",
+			}})
+		}
+	}
+
+	for _, f := range fields {
+		fv := v.Field(f.index)
+
+		if f.source != SourceBody {
+			raw := rawValue(c, f)
+			if raw == "" {
+				if f.required {
+					violations = append(violations, f.violation("is required"))
+				}
+				continue
+			}
+
+			if err := setValue(fv, raw); err != nil {
+				violations = append(violations, f.violation(err.Error()))
+				continue
+			}
+		} else if f.required && fv.IsZero() {
+			violations = append(violations, f.violation("is required"))
+			continue
+		}
+
+		// Необязательное поле тела без значения не проверяется
+		if f.source == SourceBody && fv.IsZero() {
+			continue
+		}
+
+		violations = append(violations, f.check(fv)...)
+
+		if f.source == SourceBody {
+			violations = append(violations, validateElems(fv, f.name)...)
+		}
+	}
+
+	if len(violations) > 0 {
+		return badRequest(violations)
+	}
+
+	return nil
+}
+
+// validateElems проверяет элементы среза структур из тела запроса.
+func validateElems(v reflect.Value, name string) []dto.FieldError {
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Struct || v.Type().Elem() == timeType {
+		return nil
+	}
+
+	fields, err := fieldsOf(v.Type().Elem())
+	if err != nil {
+		panic(err)
+	}
+
+	var violations []dto.FieldError
+	for i := range v.Len() {
+		elem := v.Index(i)
+		prefix := fmt.Sprintf("%s[%d].", name, i)
+
+		for _, f := range fields {
+			f.name = prefix + f.name
+			f.source = SourceBody
+			fv := elem.Field(f.index)
+
+			if fv.IsZero() {
+				if f.required {
+					violations = append(violations, f.violation("is required"))
+				}
+				continue
+			}
+
+			violations = append(violations, f.check(fv)...)
+			violations = append(violations, validateElems(fv, f.name)...)
+		}
+	}
+
+	return violations
+}
+
+func badRequest(violations []dto.FieldError) *dto.HTTPError {
+	return &dto.HTTPError{
+		Status:  fiber.StatusBadRequest,
+		Error:   "bad_request",
+		Message: "request validation failed",
+		Details: violations,
+	}
+}
+
+type field struct {
+	index    int
+	name     string
+	source   string
+	required bool
+	min      *float64
+	max      *float64
+	pattern  *regexp.Regexp
+}
+
+func (f field) violation(message string) dto.FieldError {
+	return dto.FieldError{Field: f.name, Source: f.source, Message: message}
+}
+
+// check применяет min/max к числам, к длине строк и срезов, и regex к строкам.
+func (f field) check(v reflect.Value) []dto.FieldError {
+	// Указатель в теле отличает отсутствующее поле от нулевого; проверяется значение
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	var violations []dto.FieldError
+
+	var size float64
+	unit := ""
+	switch v.Kind() {
+	case reflect.String:
+		size, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+	case reflect.Slice:
+		size, unit = float64(v.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		size = v.Float()
+	}
+
+	if f.min != nil && size < *f.min {
+		violations = append(violations, f.violation(fmt.Sprintf("must be at least %s%s", formatBound(*f.min), unit)))
+	}
+	if f.max != nil && size > *f.max {
+		violations = append(violations, f.violation(fmt.Sprintf("must be at most %s%s", formatBound(*f.max), unit)))
+	}
+
+	if f.pattern != nil {
+		values := []string{}
+		switch v.Kind() {
+		case reflect.String:
+			values = append(values, v.String())
+		case reflect.Slice:
+			for i := range v.Len() {
+				values = append(values, v.Index(i).String())
+			}
+		}
+
+		for _, s := range values {
+			if !f.pattern.MatchString(s) {
+				violations = append(violations, f.violation(fmt.Sprintf("must match %s", f.pattern.String())))
+				break
+			}
+		}
+	}
+
+	return violations
+}
+
+func formatBound(b float64) string {
+	return strconv.FormatFloat(b, 'f', -1, 64)
+}
+
+// Разбор тегов кешируется по типу: структуры запросов неизменны
+var fieldCache sync.Map
+
+func fieldsOf(t reflect.Type) ([]field, error) {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field), nil
+	}
+
+	fields := make([]field, 0, t.NumField())
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := field{index: i}
+		switch {
+		case sf.Tag.Get(SourcePath) != "":
+			f.source, f.name = SourcePath, sf.Tag.Get(SourcePath)
+		case sf.Tag.Get(SourceQuery) != "":
+			f.source, f.name = SourceQuery, sf.Tag.Get(SourceQuery)
+		case sf.Tag.Get(SourceHeader) != "":
+			f.source, f.name = SourceHeader, sf.Tag.Get(SourceHeader)
+		default:
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			f.source, f.name = SourceBody, name
+		}
+
+		if err := parseRules(&f, sf.Tag.Get("validate")); err != nil {
+			return nil, fmt.Errorf("binding: %s.%s: %w", t.Name(), sf.Name, err)
+		}
+
+		fields = append(fields, f)
+	}
+
+	fieldCache.Store(t, fields)
+
+	return fields, nil
+}
+
+func parseRules(f *field, tag string) error {
+	for tag != "" {
+		var rule string
+		if strings.HasPrefix(tag, "regex=") {
+			rule, tag = tag, ""
+		} else {
+			rule, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			f.required = true
+		case "min", "max":
+			bound, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s rule %q", name, arg)
+			}
+			if name == "min" {
+				f.min = &bound
+			} else {
+				f.max = &bound
+			}
+		case "regex":
+			pattern, err := regexp.Compile(arg)
+			if err != nil {
+				return fmt.Errorf("invalid regex rule: %w", err)
+			}
+			f.pattern = pattern
+		default:
+			return fmt.Errorf("unknown rule %q", name)
+		}
+	}
+
+	return nil
+}
+
+func hasBodyFields(fields []field) bool {
+	for _, f := range fields {
+		if f.source == SourceBody {
+			return true
+		}
+	}
+
+	return false
+}
+
+func rawValue(c *fiber.Ctx, f field) string {
+	switch f.source {
+	case SourcePath:
+		return c.Params(f.name)
+	case SourceQuery:
+		return c.Query(f.name)
+	default:
+		return c.Get(f.name)
+	}
+}
+
+// setValue переводит строку из path/query/заголовка в тип поля.
+// Срезы заполняются из значений через запятую.
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("must be an RFC 3339 timestamp")
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a non-negative integer")
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		fl, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		v.SetFloat(fl)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			panic(fmt.Sprintf("binding: unsupported slice type %s", v.Type()))
+		}
+		parts := strings.Split(raw, ",")
+		values := make([]string, 0, len(parts))
+		for _, p := range parts {
+			if p = strings.TrimSpace(p); p != "" {
+				values = append(values, p)
+			}
+		}
+		v.Set(reflect.ValueOf(values))
+	default:
+		panic(fmt.Sprintf("binding: unsupported field type %s", v.Type()))
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package binding
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"github.com/gofiber/fiber/v2"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testOperation struct {
+	Op     string  `json:"op" validate:"required,regex=^(upsert|delete)$"`
+	Amount float64 `json:"amount" validate:"min=0"`
+}
+
+type testRequest struct {
+	Id         int             `path:"id" validate:"required,min=1"`
+	Symbols    []string        `query:"symbols" validate:"max=2,regex=^[A-Z]+$"`
+	From       time.Time       `query:"from"`
+	Limit      int             `query:"limit" validate:"min=1,max=100"`
+	Key        string          `header:"X-Key" validate:"min=3"`
+	Name       *string         `json:"name" validate:"min=1,max=5"`
+	Operations []testOperation `json:"operations" validate:"max=2"`
+}
+
+// bindRequest прогоняет запрос через Bind в обработчике Fiber и возвращает результат
+func bindRequest(t *testing.T, target string, header map[string]string, body string) (testRequest, *dto.HTTPError) {
+	t.Helper()
+
+	var (
+		req     testRequest
+		httpErr *dto.HTTPError
+	)
+
+	app := fiber.New()
+	app.Post("/items/:id", func(c *fiber.Ctx) error {
+		httpErr = Bind(c, &req)
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	r := httptest.NewRequest(fiber.MethodPost, target, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	for k, v := range header {
+		r.Header.Set(k, v)
+	}
+
+	res, err := app.Test(r)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	return req, httpErr
+}
+
+func TestBindViolations(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		header map[string]string
+		body   string
+		want   []dto.FieldError
+	}{
+		{
+			name:   "valid",
+			target: "/items/7?symbols=BTC,ETH&limit=10&from=2024-01-02T03:04:05Z",
+			header: map[string]string{"X-Key": "abc"},
+			body:   `{"name":"main","operations":[{"op":"upsert","amount":1}]}`,
+		},
+		{
+			name:   "path below min",
+			target: "/items/0",
+			want:   []dto.FieldError{{Field: "id", Source: SourcePath, Message: "must be at least 1"}},
+		},
+		{
+			name:   "path not a number",
+			target: "/items/abc",
+			want:   []dto.FieldError{{Field: "id", Source: SourcePath, Message: "must be an integer"}},
+		},
+		{
+			name:   "query errors are collected",
+			target: "/items/1?limit=500&from=yesterday&symbols=BTC,eth,SOL",
+			want: []dto.FieldError{
+				{Field: "symbols", Source: SourceQuery, Message: "must be at most 2 items"},
+				{Field: "symbols", Source: SourceQuery, Message: "must match ^[A-Z]+$"},
+				{Field: "from", Source: SourceQuery, Message: "must be an RFC 3339 timestamp"},
+				{Field: "limit", Source: SourceQuery, Message: "must be at most 100"},
+			},
+		},
+		{
+			name:   "header too short",
+			target: "/items/1",
+			header: map[string]string{"X-Key": "ab"},
+			want:   []dto.FieldError{{Field: "X-Key", Source: SourceHeader, Message: "must be at least 3 characters"}},
+		},
+		{
+			name:   "body string length counts runes",
+			target: "/items/1",
+			body:   `{"name":"привет"}`,
+			want:   []dto.FieldError{{Field: "name", Source: SourceBody, Message: "must be at most 5 characters"}},
+		},
+		{
+			name:   "empty pointer string is checked",
+			target: "/items/1",
+			body:   `{"name":""}`,
+			want:   []dto.FieldError{{Field: "name", Source: SourceBody, Message: "must be at least 1 characters"}},
+		},
+		{
+			name:   "nested elements",
+			target: "/items/1",
+			body:   `{"operations":[{"op":"upsert","amount":-1},{"amount":2}]}`,
+			want: []dto.FieldError{
+				{Field: "operations[0].amount", Source: SourceBody, Message: "must be at least 0"},
+				{Field: "operations[1].op", Source: SourceBody, Message: "is required"},
+			},
+		},
+		{
+			name:   "too many elements",
+			target: "/items/1",
+			body:   `{"operations":[{"op":"upsert"},{"op":"upsert"},{"op":"rename"}]}`,
+			want: []dto.FieldError{
+				{Field: "operations", Source: SourceBody, Message: "must be at most 2 items"},
+				{Field: "operations[2].op", Source: SourceBody, Message: "must match ^(upsert|delete)$"},
+			},
+		},
+		{
+			name:   "malformed body",
+			target: "/items/1",
+			body:   `{"name":`,
+			want:   []dto.FieldError{{Field: "body", Source: SourceBody, Message: "malformed request body"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, httpErr := bindRequest(t, tt.target, tt.header, tt.body)
+
+			if tt.want == nil {
+				if httpErr != nil {
+					t.Fatalf("Bind() = %+v, want no error", httpErr.Details)
+				}
+				return
+			}
+
+			if httpErr == nil {
+				t.Fatalf("Bind() = nil, want %+v", tt.want)
+			}
+			if httpErr.Status != fiber.StatusBadRequest {
+				t.Errorf("status = %d, want %d", httpErr.Status, fiber.StatusBadRequest)
+			}
+			if !reflect.DeepEqual(httpErr.Details, tt.want) {
+				t.Errorf("details = %+v, want %+v", httpErr.Details, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindValues(t *testing.T) {
+	req, httpErr := bindRequest(t, "/items/7?symbols=BTC,%20ETH,&limit=10&from=2024-01-02T03:04:05Z",
+		map[string]string{"X-Key": "secret"}, `{"name":"main","operations":[{"op":"delete"}]}`)
+	if httpErr != nil {
+		t.Fatalf("Bind() = %+v", httpErr.Details)
+	}
+
+	want := testRequest{
+		Id:         7,
+		Symbols:    []string{"BTC", "ETH"},
+		From:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Limit:      10,
+		Key:        "secret",
+		Name:       req.Name,
+		Operations: []testOperation{{Op: "delete"}},
+	}
+	if req.Name == nil || *req.Name != "main" {
+		t.Errorf("Name = %v, want main", req.Name)
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("Bind() = %+v, want %+v", req, want)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		tag         string
+		wantErr     bool
+		wantPattern string
+	}{
+		{name: "empty", tag: ""},
+		{name: "bounds", tag: "required,min=1,max=10.5"},
+		{name: "regex with commas", tag: "required,regex=^[a-z]{1,3}$", wantPattern: "^[a-z]{1,3}$"},
+		{name: "unknown rule", tag: "email", wantErr: true},
+		{name: "invalid bound", tag: "min=one", wantErr: true},
+		{name: "invalid regex", tag: "regex=[", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f field
+			err := parseRules(&f, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantPattern != "" && (f.pattern == nil || f.pattern.String() != tt.wantPattern) {
+				t.Errorf("pattern = %v, want %s", f.pattern, tt.wantPattern)
+			}
+		})
+	}
+}
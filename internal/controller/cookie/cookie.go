@@ -0,0 +1,118 @@
+package cookie
+
+import (
+	"crypto/rand"
+	"crypto_analyzer-api_gateway/internal/config/model"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/base64"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+const (
+	AccessTokenName  = "access_token"
+	RefreshTokenName = "refresh_token"
+	CSRFTokenName    = "csrf_token"
+	CSRFHeader       = "X-CSRF-Token"
+	OIDCBindingName  = "oidc_binding"
+
+	// refresh-токен нужен только эндпоинтам /auth, остальным маршрутам он не отправляется
+	refreshTokenPath = "/auth"
+	oidcPath         = "/auth/oidc"
+
+	// AccessTokenLocal — ключ c.Locals с access-токеном текущего запроса
+	AccessTokenLocal = "access_token"
+	// AuthSourceLocal — ключ c.Locals с источником токена запроса
+	AuthSourceLocal  = "auth_source"
+	AuthSourceCookie = "cookie"
+	AuthSourceHeader = "header"
+	AuthSourceAPIKey = "api_key"
+)
+
+func expiresAt(token string) time.Time {
+	claims, err := jwt.ParseUnverified(token)
+	if err != nil {
+		return time.Time{}
+	}
+
+	exp, _ := claims.Expiry()
+	return exp
+}
+
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate csrf token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func set(c *fiber.Ctx, cfg *model.CookieConfig, name, value, path string, httpOnly bool, expires time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:        name,
+		Value:       value,
+		Path:        path,
+		Domain:      cfg.Domain,
+		Expires:     expires,
+		Secure:      cfg.Secure,
+		HTTPOnly:    httpOnly,
+		SameSite:    cfg.SameSite,
+		SessionOnly: expires.IsZero(),
+	})
+}
+
+// SetTokens кладёт токены в HttpOnly-куки и выставляет новый CSRF-токен (double-submit).
+// Возвращает CSRF-токен, чтобы клиент мог получить его и из тела ответа.
+func SetTokens(c *fiber.Ctx, cfg *model.CookieConfig, tokens auth.Tokens) (string, error) {
+	csrfToken, err := newCSRFToken()
+	if err != nil {
+		return "", err
+	}
+
+	accessExpires := expiresAt(tokens.AccessToken)
+
+	set(c, cfg, AccessTokenName, tokens.AccessToken, "/", true, accessExpires)
+	set(c, cfg, RefreshTokenName, tokens.RefreshToken, refreshTokenPath, true, expiresAt(tokens.RefreshToken))
+	// CSRF-токен читается JS-клиентом и отправляется в заголовке X-CSRF-Token
+	set(c, cfg, CSRFTokenName, csrfToken, "/", false, accessExpires)
+
+	return csrfToken, nil
+}
+
+func Clear(c *fiber.Ctx, cfg *model.CookieConfig) {
+	expired := time.Unix(0, 0)
+
+	set(c, cfg, AccessTokenName, "", "/", true, expired)
+	set(c, cfg, RefreshTokenName, "", refreshTokenPath, true, expired)
+	set(c, cfg, CSRFTokenName, "", "/", false, expired)
+}
+
+// SetOIDCBinding привязывает незавершённый вход через OIDC к браузеру.
+// SameSite=Lax, иначе куки не придёт с редиректом от провайдера на callback.
+func SetOIDCBinding(c *fiber.Ctx, cfg *model.CookieConfig, value string, ttl time.Duration) {
+	c.Cookie(&fiber.Cookie{
+		Name:     OIDCBindingName,
+		Value:    value,
+		Path:     oidcPath,
+		Domain:   cfg.Domain,
+		Expires:  time.Now().Add(ttl),
+		Secure:   cfg.Secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func ClearOIDCBinding(c *fiber.Ctx, cfg *model.CookieConfig) {
+	c.Cookie(&fiber.Cookie{
+		Name:     OIDCBindingName,
+		Path:     oidcPath,
+		Domain:   cfg.Domain,
+		Expires:  time.Unix(0, 0),
+		Secure:   cfg.Secure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
@@ -3,70 +3,304 @@ package auth
 import (
 	"context"
 	authpb "crypto_analyzer-api_gateway/gen/go/auth"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	authDomain "crypto_analyzer-api_gateway/internal/domain/auth"
 	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"crypto_analyzer-api_gateway/internal/infrastructure/metrics"
+	"crypto_analyzer-api_gateway/pkg/identity"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 	"strings"
+	"time"
 )
 
-var _ AuthMiddlewareVerifierContract = (*grpcVerifierAdapter)(nil)
+var (
+	_ AuthMiddlewareVerifierContract = (*grpcVerifierAdapter)(nil)
+	_ LocalTokenVerifierContract     = (*jwt.Verifier)(nil)
+)
 
 type AuthMiddlewareVerifierContract interface {
 	Verify(ctx context.Context, in *authpb.VerifyRequest) (*authpb.VerifyResponse, error)
 }
 
+type LocalTokenVerifierContract interface {
+	Verify(ctx context.Context, token string) (jwt.Claims, error)
+}
+
+// APIKeyResolverContract возвращает пользователя с правами API-ключа или nil, если ключ не найден.
+type APIKeyResolverContract interface {
+	Resolve(ctx context.Context, secret string) (*portfolio.User, error)
+}
+
 type grpcVerifierAdapter struct {
 	grpcClient authpb.AuthServiceClient
 }
 
+type VerifierOptions struct {
+	Revocations    authDomain.RevocationListContract
+	VerifyCache    authDomain.VerifyCacheContract
+	VerifyCacheTTL time.Duration
+	// LocalVerifier проверяет подпись токена в gateway. nil — только удалённая проверка через Verify.
+	LocalVerifier LocalTokenVerifierContract
+	// RemoteFallback разрешает уходить в Verify, если ключи подписи недоступны.
+	RemoteFallback bool
+	// CookieAuth разрешает брать access-токен из HttpOnly-куки, если нет заголовка Authorization.
+	CookieAuth bool
+	APIKeys    APIKeyResolverContract
+}
+
 type AuthMiddlewareVerifier struct {
 	authClient AuthMiddlewareVerifierContract
+	opts       VerifierOptions
 }
 
-func NewAuthMiddlewareVerifier(authClient authpb.AuthServiceClient) *AuthMiddlewareVerifier {
+func NewAuthMiddlewareVerifier(authClient authpb.AuthServiceClient, opts VerifierOptions) *AuthMiddlewareVerifier {
 	adapter := &grpcVerifierAdapter{grpcClient: authClient}
-	return &AuthMiddlewareVerifier{authClient: adapter}
+	return &AuthMiddlewareVerifier{
+		authClient: adapter,
+		opts:       opts,
+	}
 }
 
 func (m *grpcVerifierAdapter) Verify(ctx context.Context, in *authpb.VerifyRequest) (*authpb.VerifyResponse, error) {
 	return m.grpcClient.Verify(ctx, in)
 }
 
+// AuthVerify проверяет токен локально (если настроено) с откатом на Auth Service.
 func (m *AuthMiddlewareVerifier) AuthVerify(c *fiber.Ctx) error {
+	return m.handle(c, false, false)
+}
+
+// AuthVerifyStrict всегда проверяет токен в Auth Service в обход кеша.
+// Используется на чувствительных маршрутах.
+func (m *AuthMiddlewareVerifier) AuthVerifyStrict(c *fiber.Ctx) error {
+	return m.handle(c, true, false)
+}
+
+// AuthOptional пропускает запрос без токена анонимно (без "user" в Locals),
+// но невалидный или отозванный токен по-прежнему отклоняется с 401.
+// Используется на публичных маршрутах, которые персонализируют ответ.
+func (m *AuthMiddlewareVerifier) AuthOptional(c *fiber.Ctx) error {
+	return m.handle(c, false, true)
+}
+
+// APIKeyOr аутентифицирует запрос по заголовку X-API-Key, а без него передаёт управление next
+// (AuthVerify или AuthVerifyStrict).
+func (m *AuthMiddlewareVerifier) APIKeyOr(next fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		secret := c.Get("X-API-Key")
+		if secret == "" {
+			return next(c)
+		}
+
+		return m.handleAPIKey(c, secret)
+	}
+}
+
+func (m *AuthMiddlewareVerifier) handleAPIKey(c *fiber.Ctx, secret string) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, err := m.opts.APIKeys.Resolve(ctx, secret)
+	if err != nil {
+		log.Error("failed to resolve api key", zap.Error(err))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "service unavailable"})
+	}
+	if user == nil {
+		log.Warn("unknown api key")
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid api key"})
+	}
+
+	c.Locals("user", user)
+	c.Locals(cookie.AuthSourceLocal, cookie.AuthSourceAPIKey)
+
+	log = logger.WithTraceID(ctx, log).With(
+		zap.String("userID", user.Id),
+		zap.String("username", user.Username),
+		zap.String("auth", cookie.AuthSourceAPIKey),
+	)
+	c.SetUserContext(logger.WithLogger(identity.NewContext(ctx, identityFromUser(user)), log))
+
+	return c.Next()
+}
+
+func (m *AuthMiddlewareVerifier) handle(c *fiber.Ctx, strict, optional bool) error {
 	ctx := c.UserContext()
 	log := logger.FromContext(ctx)
 
 	// Проверка токена
-	authHeader := c.Get("Authorization")
-	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+	token, source := m.extractToken(c)
+	if token == "" && optional {
+		return c.Next()
+	}
+	if token == "" {
 		log.Warn("missing auth header")
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
 	}
 
-	token := strings.TrimPrefix(authHeader, "Bearer ")
+	revoked, err := m.opts.Revocations.IsRevoked(ctx, token)
+	if err != nil {
+		log.Error("failed to check revocation list", zap.Error(err))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "service unavailable"})
+	}
+	if revoked {
+		log.Warn("revoked token used")
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "token revoked"})
+	}
+
+	var user *portfolio.User
+	if strict {
+		user, err = m.verifyRemote(ctx, token)
+	} else {
+		user, err = m.verifyToken(ctx, token)
+	}
+	if err != nil {
+		log.Warn("failed to verify token", zap.Bool("strict", strict), zap.Error(err))
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "failed to verify"})
+	}
+
+	c.Locals("user", user)
+	c.Locals(cookie.AuthSourceLocal, source)
+	c.Locals(cookie.AccessTokenLocal, token)
+
+	log = logger.WithTraceID(ctx, log).With(
+		zap.String("userID", user.Id),
+		zap.String("username", user.Username),
+	)
+	c.SetUserContext(logger.WithLogger(identity.NewContext(ctx, identityFromUser(user)), log))
 
+	return c.Next()
+}
+
+// extractToken берёт токен из заголовка Authorization, а в cookie-режиме — из куки access_token.
+func (m *AuthMiddlewareVerifier) extractToken(c *fiber.Ctx) (string, string) {
+	if authHeader := c.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer "), cookie.AuthSourceHeader
+	}
+
+	if m.opts.CookieAuth {
+		if token := c.Cookies(cookie.AccessTokenName); token != "" {
+			return token, cookie.AuthSourceCookie
+		}
+	}
+
+	return "", ""
+}
+
+func (m *AuthMiddlewareVerifier) verifyToken(ctx context.Context, token string) (*portfolio.User, error) {
+	if m.opts.LocalVerifier == nil {
+		return m.verifyCached(ctx, token)
+	}
+
+	claims, err := m.opts.LocalVerifier.Verify(ctx, token)
+	if err == nil {
+		return userFromClaims(claims), nil
+	}
+
+	// Откат допустим только при недоступности ключей; невалидный токен отклоняется сразу
+	if m.opts.RemoteFallback && errors.Is(err, jwt.ErrKeysUnavailable) {
+		logger.FromContext(ctx).Warn("signing keys unavailable, falling back to remote verify", zap.Error(err))
+		return m.verifyCached(ctx, token)
+	}
+
+	return nil, err
+}
+
+func (m *AuthMiddlewareVerifier) verifyCached(ctx context.Context, token string) (*portfolio.User, error) {
+	log := logger.FromContext(ctx)
+
+	cached, err := m.opts.VerifyCache.Get(ctx, token)
+	if err != nil {
+		// Redis недоступен — проверяем токен напрямую в Auth Service
+		log.Warn("failed to read verify cache", zap.Error(err))
+	}
+	if cached != nil {
+		metrics.IncVerifyCacheHit()
+		return cached, nil
+	}
+	metrics.IncVerifyCacheMiss()
+
+	user, err := m.verifyRemote(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl := m.cacheTTL(token); ttl > 0 {
+		if err := m.opts.VerifyCache.Set(ctx, token, user, ttl); err != nil {
+			log.Warn("failed to write verify cache", zap.Error(err))
+		}
+	}
+
+	return user, nil
+}
+
+func (m *AuthMiddlewareVerifier) verifyRemote(ctx context.Context, token string) (*portfolio.User, error) {
 	mdCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", token))
 
 	res, err := m.authClient.Verify(mdCTX, &authpb.VerifyRequest{})
 	if err != nil {
-		log.Warn("failed to verify token", zap.Error(err))
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "failed to verify"})
+		return nil, err
 	}
 
-	user := &portfolio.User{
+	return &portfolio.User{
 		Id:       res.UserId,
 		Username: res.Username,
 		Email:    res.Email,
+		Roles:    res.Roles,
+		Scopes:   scopesOrDefault(res.Scopes),
+	}, nil
+}
+
+// cacheTTL ограничивает время жизни записи сроком действия токена.
+// Если exp прочитать не удалось, результат не кешируется.
+func (m *AuthMiddlewareVerifier) cacheTTL(token string) time.Duration {
+	claims, err := jwt.ParseUnverified(token)
+	if err != nil {
+		return 0
 	}
 
-	c.Locals("user", user)
+	exp, ok := claims.Expiry()
+	if !ok {
+		return 0
+	}
 
-	log = logger.WithTraceID(ctx, log).With(
-		zap.String("userID", res.UserId),
-		zap.String("username", res.Username),
-	)
+	return min(m.opts.VerifyCacheTTL, time.Until(exp))
+}
 
-	return c.Next()
+func userFromClaims(claims jwt.Claims) *portfolio.User {
+	id := claims.UserID
+	if id == "" {
+		id = claims.Subject
+	}
+
+	return &portfolio.User{
+		Id:       id,
+		Username: claims.Username,
+		Email:    claims.Email,
+		Roles:    claims.Roles,
+		Scopes:   scopesOrDefault(claims.Scopes()),
+	}
+}
+
+// identityFromUser — личность, которую gRPC interceptor подпишет для backend-сервисов
+func identityFromUser(user *portfolio.User) identity.Identity {
+	return identity.Identity{
+		UserID:       user.Id,
+		Username:     user.Username,
+		Roles:        user.Roles,
+		Scopes:       user.Scopes,
+		PortfolioIDs: user.AllowedPortfolioIds,
+	}
+}
+
+func scopesOrDefault(scopes []string) []string {
+	if len(scopes) == 0 {
+		return portfolio.DefaultScopes
+	}
+
+	return scopes
 }
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/audit"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"crypto_analyzer-api_gateway/pkg/identity"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+const (
+	ActAsHeader = "X-Act-As"
+	// ImpersonationOverrideHeader — причина, по которой деструктивный запрос разрешён под чужой личностью
+	ImpersonationOverrideHeader = "X-Impersonation-Override"
+
+	// ImpersonatorLocal — ключ c.Locals с настоящим пользователем (администратором)
+	ImpersonatorLocal = "impersonator"
+
+	maxOverrideReasonLength = 256
+)
+
+type UserLookupContract interface {
+	GetUser(ctx context.Context, userId string) (portfolio.User, error)
+}
+
+// Impersonation позволяет администратору выполнять запросы от имени пользователя из X-Act-As.
+// Каждый такой запрос записывается в журнал аудита; без записи запрос не выполняется.
+type Impersonation struct {
+	users UserLookupContract
+	audit audit.AuditLogContract
+}
+
+func NewImpersonation(users UserLookupContract, auditLog audit.AuditLogContract) *Impersonation {
+	return &Impersonation{users: users, audit: auditLog}
+}
+
+func (i *Impersonation) record(c *fiber.Ctx, action string, actor *portfolio.User, targetId, reason string) error {
+	return i.audit.Append(c.UserContext(), audit.Record{
+		Time:          time.Now(),
+		Action:        action,
+		ActorId:       actor.Id,
+		ActorUsername: actor.Username,
+		TargetId:      targetId,
+		Method:        c.Method(),
+		Path:          c.Path(),
+		RequestId:     identity.RequestIDFromContext(c.UserContext()),
+		IP:            c.IP(),
+		Reason:        reason,
+	})
+}
+
+// ActAs ставится после AuthVerify. Без заголовка X-Act-As ничего не меняет.
+func (i *Impersonation) ActAs(c *fiber.Ctx) error {
+	targetId := c.Get(ActAsHeader)
+	if targetId == "" {
+		return c.Next()
+	}
+
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	actor, ok := c.Locals("user").(*portfolio.User)
+	if !ok || actor == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	// API-ключи не дают права действовать от чужого имени, даже если выпущены администратором
+	if !actor.HasRole(portfolio.RoleAdmin) || c.Locals(cookie.AuthSourceLocal) == cookie.AuthSourceAPIKey {
+		log.Warn("impersonation denied", zap.String("user_id", actor.Id), zap.String("target_id", targetId))
+		return forbidden(c, "impersonation requires admin role")
+	}
+
+	if targetId == actor.Id {
+		return c.Next()
+	}
+
+	target, err := i.users.GetUser(ctx, targetId)
+	if err != nil {
+		if st, _ := status.FromError(err); st.Code() == codes.NotFound {
+			return c.Status(fiber.StatusNotFound).JSON(dto.HTTPError{
+				Status:  fiber.StatusNotFound,
+				Error:   "not_found",
+				Message: "impersonated user not found",
+			})
+		}
+
+		log.Error("failed to load impersonated user", zap.String("target_id", targetId), zap.Error(err))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "service unavailable"})
+	}
+	target.Scopes = scopesOrDefault(target.Scopes)
+
+	if err := i.record(c, audit.ActionImpersonate, actor, target.Id, ""); err != nil {
+		log.Error("failed to write impersonation audit record", zap.Error(err))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "service unavailable"})
+	}
+
+	c.Locals("user", &target)
+	c.Locals(ImpersonatorLocal, actor)
+	c.Set("X-Acting-As", target.Id)
+
+	id := identityFromUser(&target)
+	id.ActorID = actor.Id
+
+	log = log.With(
+		zap.String("userID", target.Id),
+		zap.String("username", target.Username),
+		zap.String("impersonatorID", actor.Id),
+		zap.String("impersonatorUsername", actor.Username),
+	)
+	log.Info("request under impersonation")
+	c.SetUserContext(logger.WithLogger(identity.NewContext(ctx, id), log))
+
+	return c.Next()
+}
+
+// BlockImpersonated закрывает деструктивный маршрут для запросов под чужой личностью.
+// Запрос пропускается только с заголовком X-Impersonation-Override, содержащим причину,
+// и эта причина попадает в журнал аудита.
+func (i *Impersonation) BlockImpersonated(c *fiber.Ctx) error {
+	actor, ok := c.Locals(ImpersonatorLocal).(*portfolio.User)
+	if !ok || actor == nil {
+		return c.Next()
+	}
+
+	log := logger.FromContext(c.UserContext())
+
+	reason := c.Get(ImpersonationOverrideHeader)
+	if reason == "" {
+		log.Warn("destructive route blocked under impersonation")
+		return c.Status(fiber.StatusForbidden).JSON(dto.HTTPError{
+			Status:  fiber.StatusForbidden,
+			Error:   "impersonation_blocked",
+			Message: "destructive operations are blocked under impersonation, set " + ImpersonationOverrideHeader,
+		})
+	}
+	if len(reason) > maxOverrideReasonLength {
+		reason = reason[:maxOverrideReasonLength]
+	}
+
+	var targetId string
+	if user, ok := c.Locals("user").(*portfolio.User); ok && user != nil {
+		targetId = user.Id
+	}
+
+	if err := i.record(c, audit.ActionImpersonateOverride, actor, targetId, reason); err != nil {
+		log.Error("failed to write impersonation audit record", zap.Error(err))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "service unavailable"})
+	}
+
+	log.Warn("impersonation override used", zap.String("reason", reason))
+
+	return c.Next()
+}
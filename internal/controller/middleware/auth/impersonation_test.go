@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/domain/audit"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/pkg/identity"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type fakeUsers map[string]portfolio.User
+
+func (f fakeUsers) GetUser(_ context.Context, userId string) (portfolio.User, error) {
+	if userId == "unavailable" {
+		return portfolio.User{}, status.Error(codes.Unavailable, "auth down")
+	}
+
+	user, ok := f[userId]
+	if !ok {
+		return portfolio.User{}, status.Error(codes.NotFound, "user not found")
+	}
+
+	return user, nil
+}
+
+type fakeAuditLog struct {
+	records []audit.Record
+	err     error
+}
+
+func (f *fakeAuditLog) Append(_ context.Context, record audit.Record) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.records = append(f.records, record)
+	return nil
+}
+
+var (
+	testAdmin  = portfolio.User{Id: "1", Username: "admin", Roles: []string{portfolio.RoleAdmin}}
+	testMember = portfolio.User{Id: "2", Username: "member"}
+	testTarget = portfolio.User{Id: "3", Username: "target"}
+)
+
+// reached — что увидел обработчик маршрута после middleware
+type reached struct {
+	user         *portfolio.User
+	impersonator *portfolio.User
+	identity     identity.Identity
+}
+
+func newImpersonationApp(i *Impersonation, actor *portfolio.User, source string, got **reached,
+	handlers ...fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if actor != nil {
+			user := *actor
+			c.Locals("user", &user)
+		}
+		c.Locals(cookie.AuthSourceLocal, source)
+		return c.Next()
+	})
+
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		r := &reached{}
+		r.user, _ = c.Locals("user").(*portfolio.User)
+		r.impersonator, _ = c.Locals(ImpersonatorLocal).(*portfolio.User)
+		r.identity, _ = identity.FromContext(c.UserContext())
+		*got = r
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Delete("/portfolio/:id", handlers...)
+
+	return app
+}
+
+func TestActAs(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      *portfolio.User
+		source     string
+		actAs      string
+		auditErr   error
+		wantStatus int
+		// wantUser — пользователь, от имени которого выполнен запрос; пусто — запрос не дошёл до обработчика
+		wantUser  string
+		wantAudit bool
+	}{
+		{name: "no header", actor: &testMember, source: cookie.AuthSourceHeader, wantStatus: fiber.StatusOK, wantUser: "2"},
+		{name: "admin acts as user", actor: &testAdmin, source: cookie.AuthSourceHeader, actAs: "3",
+			wantStatus: fiber.StatusOK, wantUser: "3", wantAudit: true},
+		{name: "admin acts as self", actor: &testAdmin, source: cookie.AuthSourceCookie, actAs: "1",
+			wantStatus: fiber.StatusOK, wantUser: "1"},
+		{name: "not an admin", actor: &testMember, source: cookie.AuthSourceHeader, actAs: "3",
+			wantStatus: fiber.StatusForbidden},
+		{name: "admin api key", actor: &testAdmin, source: cookie.AuthSourceAPIKey, actAs: "3",
+			wantStatus: fiber.StatusForbidden},
+		{name: "no user in context", source: cookie.AuthSourceHeader, actAs: "3", wantStatus: fiber.StatusUnauthorized},
+		{name: "unknown target", actor: &testAdmin, source: cookie.AuthSourceHeader, actAs: "404",
+			wantStatus: fiber.StatusNotFound},
+		{name: "auth service unavailable", actor: &testAdmin, source: cookie.AuthSourceHeader, actAs: "unavailable",
+			wantStatus: fiber.StatusServiceUnavailable},
+		{name: "audit log unavailable", actor: &testAdmin, source: cookie.AuthSourceHeader, actAs: "3",
+			auditErr: errors.New("redis down"), wantStatus: fiber.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditLog := &fakeAuditLog{err: tt.auditErr}
+			i := NewImpersonation(fakeUsers{"3": testTarget}, auditLog)
+
+			var got *reached
+			app := newImpersonationApp(i, tt.actor, tt.source, &got, i.ActAs)
+
+			req := httptest.NewRequest(fiber.MethodDelete, "/portfolio/7", nil)
+			if tt.actAs != "" {
+				req.Header.Set(ActAsHeader, tt.actAs)
+			}
+
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			if tt.wantUser == "" {
+				if got != nil {
+					t.Fatalf("rejected request reached the handler as %+v", got.user)
+				}
+				if len(auditLog.records) != 0 {
+					t.Errorf("rejected request was audited: %+v", auditLog.records)
+				}
+				return
+			}
+
+			if got == nil || got.user == nil || got.user.Id != tt.wantUser {
+				t.Fatalf("handler user = %+v, want %s", got, tt.wantUser)
+			}
+
+			if !tt.wantAudit {
+				if got.impersonator != nil || len(auditLog.records) != 0 || res.Header.Get("X-Acting-As") != "" {
+					t.Errorf("request without impersonation is marked as impersonated")
+				}
+				return
+			}
+
+			if got.impersonator == nil || got.impersonator.Id != tt.actor.Id {
+				t.Errorf("impersonator = %+v, want %s", got.impersonator, tt.actor.Id)
+			}
+			if !slices.Equal(got.user.Scopes, portfolio.DefaultScopes) {
+				t.Errorf("target scopes = %v, want defaults", got.user.Scopes)
+			}
+			if got.identity.UserID != tt.wantUser || got.identity.ActorID != tt.actor.Id {
+				t.Errorf("identity = %+v, want user %s acting as actor %s", got.identity, tt.wantUser, tt.actor.Id)
+			}
+			if res.Header.Get("X-Acting-As") != tt.wantUser {
+				t.Errorf("X-Acting-As = %q", res.Header.Get("X-Acting-As"))
+			}
+
+			if len(auditLog.records) != 1 {
+				t.Fatalf("audit records = %d, want 1", len(auditLog.records))
+			}
+			record := auditLog.records[0]
+			if record.Action != audit.ActionImpersonate || record.ActorId != tt.actor.Id ||
+				record.TargetId != tt.wantUser || record.Method != fiber.MethodDelete || record.Path != "/portfolio/7" {
+				t.Errorf("audit record = %+v", record)
+			}
+		})
+	}
+}
+
+func TestBlockImpersonated(t *testing.T) {
+	longReason := strings.Repeat("r", maxOverrideReasonLength+10)
+
+	tests := []struct {
+		name       string
+		actAs      string
+		override   string
+		auditErr   error
+		wantStatus int
+		wantReason string
+	}{
+		{name: "own request", wantStatus: fiber.StatusOK},
+		{name: "impersonated without override", actAs: "3", wantStatus: fiber.StatusForbidden},
+		{name: "impersonated with override", actAs: "3", override: "support ticket 42",
+			wantStatus: fiber.StatusOK, wantReason: "support ticket 42"},
+		{name: "override reason is truncated", actAs: "3", override: longReason,
+			wantStatus: fiber.StatusOK, wantReason: longReason[:maxOverrideReasonLength]},
+		{name: "audit log unavailable", actAs: "3", override: "support ticket 42",
+			auditErr: errors.New("redis down"), wantStatus: fiber.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditLog := &fakeAuditLog{}
+			i := NewImpersonation(fakeUsers{"3": testTarget}, auditLog)
+
+			// Ошибка журнала включается после ActAs, чтобы проверить именно запись override
+			failAudit := func(c *fiber.Ctx) error {
+				auditLog.err = tt.auditErr
+				return c.Next()
+			}
+
+			var got *reached
+			app := newImpersonationApp(i, &testAdmin, cookie.AuthSourceHeader, &got, i.ActAs, failAudit,
+				i.BlockImpersonated)
+
+			req := httptest.NewRequest(fiber.MethodDelete, "/portfolio/7", nil)
+			if tt.actAs != "" {
+				req.Header.Set(ActAsHeader, tt.actAs)
+			}
+			if tt.override != "" {
+				req.Header.Set(ImpersonationOverrideHeader, tt.override)
+			}
+
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if res.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if (got != nil) != (tt.wantStatus == fiber.StatusOK) {
+				t.Fatalf("handler reached = %v, want %v", got != nil, tt.wantStatus == fiber.StatusOK)
+			}
+
+			var overrides []audit.Record
+			for _, r := range auditLog.records {
+				if r.Action == audit.ActionImpersonateOverride {
+					overrides = append(overrides, r)
+				}
+			}
+
+			if tt.wantReason == "" {
+				if len(overrides) != 0 {
+					t.Errorf("unexpected override records: %+v", overrides)
+				}
+				return
+			}
+
+			if len(overrides) != 1 {
+				t.Fatalf("override records = %d, want 1", len(overrides))
+			}
+			if r := overrides[0]; r.Reason != tt.wantReason || r.ActorId != testAdmin.Id || r.TargetId != testTarget.Id {
+				t.Errorf("override record = %+v", r)
+			}
+		})
+	}
+}
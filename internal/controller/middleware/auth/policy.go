@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"strconv"
+	"strings"
+)
+
+// Policy описывает права, необходимые для маршрута.
+// Пользователь должен иметь хотя бы одну из Roles (если заданы) и все Scopes.
+type Policy struct {
+	Roles  []string
+	Scopes []string
+	// PortfolioParam — имя path-параметра с id портфеля для проверки ограничений API-ключа.
+	PortfolioParam string
+	// AllPortfolios запрещает маршрут учётным данным, ограниченным отдельными портфелями.
+	AllPortfolios bool
+}
+
+// Require возвращает middleware, проверяющее Policy. Ставится после AuthVerify.
+func Require(policy Policy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		log := logger.FromContext(c.UserContext())
+
+		user, ok := c.Locals("user").(*portfolio.User)
+		if !ok || user == nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+				Status:  fiber.StatusUnauthorized,
+				Error:   "unauthorized",
+				Message: "user not found in context",
+			})
+		}
+
+		if len(policy.Roles) > 0 && !hasAnyRole(user, policy.Roles) {
+			log.Warn("access denied: missing role",
+				zap.String("user_id", user.Id),
+				zap.Strings("required_roles", policy.Roles),
+			)
+			return forbidden(c, "requires one of roles: "+strings.Join(policy.Roles, ", "))
+		}
+
+		for _, scope := range policy.Scopes {
+			if !user.HasScope(scope) {
+				log.Warn("access denied: missing scope",
+					zap.String("user_id", user.Id),
+					zap.String("required_scope", scope),
+				)
+				return forbidden(c, "missing required scope "+scope)
+			}
+		}
+
+		if policy.AllPortfolios && user.IsPortfolioRestricted() {
+			log.Warn("access denied: credentials restricted to portfolios", zap.String("user_id", user.Id))
+			return forbidden(c, "credentials are restricted to specific portfolios")
+		}
+
+		if policy.PortfolioParam != "" && user.IsPortfolioRestricted() {
+			portfolioId, err := strconv.ParseInt(c.Params(policy.PortfolioParam), 10, 32)
+			if err != nil || !user.CanAccessPortfolio(int32(portfolioId)) {
+				log.Warn("access denied: portfolio not allowed",
+					zap.String("user_id", user.Id),
+					zap.String("portfolio_id", c.Params(policy.PortfolioParam)),
+				)
+				return forbidden(c, "credentials do not grant access to this portfolio")
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+func RequireScopes(scopes ...string) fiber.Handler {
+	return Require(Policy{Scopes: scopes})
+}
+
+func RequireRoles(roles ...string) fiber.Handler {
+	return Require(Policy{Roles: roles})
+}
+
+func hasAnyRole(user *portfolio.User, roles []string) bool {
+	for _, role := range roles {
+		if user.HasRole(role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func forbidden(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusForbidden).JSON(dto.HTTPError{
+		Status:  fiber.StatusForbidden,
+		Error:   "forbidden",
+		Message: msg,
+	})
+}
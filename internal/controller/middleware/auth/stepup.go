@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	authDomain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"math"
+	"strconv"
+)
+
+const (
+	OTPHeader         = "X-OTP"
+	StepUpTokenHeader = "X-Step-Up-Token"
+)
+
+type StepUpCheckerContract interface {
+	CheckStepUp(ctx context.Context, userId, accessToken, ip, token, code string) error
+}
+
+// RequireStepUp помечает маршрут как чувствительный: пользователь с включённым TOTP
+// должен передать X-Step-Up-Token (POST /auth/step-up) или свежий код в X-OTP.
+// Ставится после AuthVerify.
+func RequireStepUp(checker StepUpCheckerContract) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		log := logger.FromContext(ctx)
+
+		user, ok := c.Locals("user").(*portfolio.User)
+		if !ok || user == nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+				Status:  fiber.StatusUnauthorized,
+				Error:   "unauthorized",
+				Message: "user not found in context",
+			})
+		}
+
+		// Step-up токен действует только с тем access-токеном, с которым выдан
+		accessToken, _ := c.Locals(cookie.AccessTokenLocal).(string)
+
+		err := checker.CheckStepUp(ctx, user.Id, accessToken, c.IP(), c.Get(StepUpTokenHeader), c.Get(OTPHeader))
+		if err == nil {
+			return c.Next()
+		}
+
+		var locked *authDomain.LockedError
+		switch {
+		case errors.Is(err, authDomain.ErrStepUpRequired), errors.Is(err, authDomain.ErrInvalidStepUp):
+			log.Warn("step-up required", zap.String("user_id", user.Id), zap.Error(err))
+
+			message := "confirm the operation with X-OTP or X-Step-Up-Token"
+			if errors.Is(err, authDomain.ErrInvalidStepUp) {
+				message = "invalid or expired step-up proof"
+			}
+
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+				Status:  fiber.StatusUnauthorized,
+				Error:   "step_up_required",
+				Message: message,
+			})
+		case errors.As(err, &locked):
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(dto.HTTPError{
+				Status:  fiber.StatusTooManyRequests,
+				Error:   "too_many_attempts",
+				Message: "too many invalid codes, try again later",
+			})
+		}
+
+		log.Error("failed to check step-up", zap.String("user_id", user.Id), zap.Error(err))
+
+		if st, ok := status.FromError(err); ok {
+			httpErr := mapper.GrpcCodeToHTTPError(st.Code(), "failed to check step-up")
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "service unavailable"})
+	}
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	authDomain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeStepUpChecker принимает только step-up токен "good", выданный к access-токену "access-1"
+type fakeStepUpChecker struct {
+	err error
+}
+
+func (f fakeStepUpChecker) CheckStepUp(_ context.Context, userId, accessToken, _, token, _ string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if token == "" {
+		return authDomain.ErrStepUpRequired
+	}
+	if token != "good" || userId != "2" || accessToken != "access-1" {
+		return authDomain.ErrInvalidStepUp
+	}
+	return nil
+}
+
+func TestRequireStepUp(t *testing.T) {
+	tests := []struct {
+		name           string
+		noUser         bool
+		accessToken    string
+		stepUpToken    string
+		checkErr       error
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{name: "token of this session", accessToken: "access-1", stepUpToken: "good", wantStatus: fiber.StatusOK},
+		{name: "token of another session", accessToken: "access-2", stepUpToken: "good",
+			wantStatus: fiber.StatusUnauthorized},
+		{name: "no proof", accessToken: "access-1", wantStatus: fiber.StatusUnauthorized},
+		{name: "no user in context", noUser: true, stepUpToken: "good", wantStatus: fiber.StatusUnauthorized},
+		{name: "locked", accessToken: "access-1", checkErr: &authDomain.LockedError{RetryAfter: 1500 * time.Millisecond},
+			wantStatus: fiber.StatusTooManyRequests, wantRetryAfter: "2"},
+		{name: "store unavailable", accessToken: "access-1", stepUpToken: "good", checkErr: errors.New("redis down"),
+			wantStatus: fiber.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if !tt.noUser {
+					c.Locals("user", &portfolio.User{Id: "2"})
+				}
+				c.Locals(cookie.AccessTokenLocal, tt.accessToken)
+				return c.Next()
+			}, RequireStepUp(fakeStepUpChecker{err: tt.checkErr}))
+			app.Delete("/portfolio/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+			req := httptest.NewRequest(fiber.MethodDelete, "/portfolio/7", nil)
+			if tt.stepUpToken != "" {
+				req.Header.Set(StepUpTokenHeader, tt.stepUpToken)
+			}
+
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if got := res.Header.Get(fiber.HeaderRetryAfter); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
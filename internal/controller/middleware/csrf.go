@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CSRFMiddleware проверяет double-submit CSRF-токен для запросов, аутентифицированных кукой.
+// Ставится после AuthVerify. Запросы с Bearer-токеном браузер сам не подставляет, поэтому пропускаются.
+func CSRFMiddleware(c *fiber.Ctx) error {
+	if c.Locals(cookie.AuthSourceLocal) != cookie.AuthSourceCookie {
+		return c.Next()
+	}
+
+	return RequireCSRF(c)
+}
+
+// RequireCSRF проверяет double-submit CSRF-токен независимо от способа аутентификации.
+// Используется на маршрутах без AuthVerify, которые читают куку сами (refresh, logout).
+func RequireCSRF(c *fiber.Ctx) error {
+	switch c.Method() {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return c.Next()
+	}
+
+	header := c.Get(cookie.CSRFHeader)
+	csrfCookie := c.Cookies(cookie.CSRFTokenName)
+	if header == "" || csrfCookie == "" || subtle.ConstantTimeCompare([]byte(header), []byte(csrfCookie)) != 1 {
+		logger.FromContext(c.UserContext()).Warn("csrf token mismatch")
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "invalid csrf token",
+		})
+	}
+
+	return c.Next()
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+func TestCSRF(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		source     string
+		header     string
+		csrfCookie string
+		// requireAlways — маршрут с RequireCSRF вместо CSRFMiddleware
+		requireAlways bool
+		wantStatus    int
+	}{
+		{
+			name:       "cookie auth with matching token",
+			method:     fiber.MethodPost,
+			source:     cookie.AuthSourceCookie,
+			header:     "token",
+			csrfCookie: "token",
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "cookie auth without header",
+			method:     fiber.MethodPost,
+			source:     cookie.AuthSourceCookie,
+			csrfCookie: "token",
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "cookie auth without csrf cookie",
+			method:     fiber.MethodDelete,
+			source:     cookie.AuthSourceCookie,
+			header:     "token",
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "cookie auth with mismatched token",
+			method:     fiber.MethodPatch,
+			source:     cookie.AuthSourceCookie,
+			header:     "token",
+			csrfCookie: "other",
+			wantStatus: fiber.StatusForbidden,
+		},
+		{
+			name:       "cookie auth safe method",
+			method:     fiber.MethodGet,
+			source:     cookie.AuthSourceCookie,
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "bearer token is not checked",
+			method:     fiber.MethodPost,
+			source:     cookie.AuthSourceHeader,
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "api key is not checked",
+			method:     fiber.MethodPost,
+			source:     cookie.AuthSourceAPIKey,
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:          "required without auth source",
+			method:        fiber.MethodPost,
+			requireAlways: true,
+			wantStatus:    fiber.StatusForbidden,
+		},
+		{
+			name:          "required with matching token",
+			method:        fiber.MethodPost,
+			header:        "token",
+			csrfCookie:    "token",
+			requireAlways: true,
+			wantStatus:    fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := CSRFMiddleware
+			if tt.requireAlways {
+				check = RequireCSRF
+			}
+
+			app := fiber.New()
+			app.Use(func(c *fiber.Ctx) error {
+				if tt.source != "" {
+					c.Locals(cookie.AuthSourceLocal, tt.source)
+				}
+				return c.Next()
+			}, check)
+			app.All("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(cookie.CSRFHeader, tt.header)
+			}
+			if tt.csrfCookie != "" {
+				req.Header.Set(fiber.HeaderCookie, cookie.CSRFTokenName+"="+tt.csrfCookie)
+			}
+
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test: %v", err)
+			}
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
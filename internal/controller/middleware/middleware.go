@@ -1,8 +1,13 @@
 package middleware
 
 import (
+	"crypto/rand"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"crypto_analyzer-api_gateway/pkg/identity"
+	"encoding/hex"
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"regexp"
 )
 
 func LoggerMiddleware(c *fiber.Ctx) error {
@@ -19,3 +24,38 @@ func TraceMiddleware(c *fiber.Ctx) error {
 	c.SetUserContext(ctx)
 	return c.Next()
 }
+
+// Клиентский X-Request-ID принимается только в безопасном виде, иначе генерируется новый
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestIDMiddleware присваивает запросу идентификатор: он возвращается в X-Request-ID,
+// пишется в логи и передаётся в backend-сервисы в подписанной личности.
+func RequestIDMiddleware(c *fiber.Ctx) error {
+	requestID := c.Get(fiber.HeaderXRequestID)
+	if !requestIDPattern.MatchString(requestID) {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return err
+		}
+		requestID = hex.EncodeToString(b)
+	}
+
+	c.Set(fiber.HeaderXRequestID, requestID)
+
+	ctx := identity.WithRequestID(c.UserContext(), requestID)
+	log := logger.FromContext(ctx).With(zap.String("requestID", requestID))
+	c.SetUserContext(logger.WithLogger(ctx, log))
+
+	return c.Next()
+}
+
+// When применяет middleware только к запросам, для которых cond возвращает true.
+func When(cond func(c *fiber.Ctx) bool, handler fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !cond(c) {
+			return c.Next()
+		}
+
+		return handler(c)
+	}
+}
@@ -0,0 +1,93 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/json"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con PortfolioController) AddTransaction(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.AddTransactionObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid transaction", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	res, err := con.portfolioUsecaseObj.AddTransaction(ctx, portfolio.Transaction{
+		PortfolioId: req.PortfolioId,
+		Type:        req.Type,
+		Symbol:      req.Symbol,
+		Amount:      req.Amount,
+		Price:       req.Price,
+		Fee:         req.Fee,
+		ExecutedAt:  req.ExecutedAt,
+		Note:        req.Note,
+	})
+	if err != nil {
+		var (
+			validationErr   *portfolio.AssetValidationError
+			insufficientErr *portfolio.InsufficientHoldingError
+		)
+
+		switch {
+		case errors.As(err, &validationErr):
+			log.Warn("invalid transaction", zap.String("user_id", user.Id), zap.Error(err))
+			httpErr := mapper.MapAssetValidationError(validationErr, binding.SourceBody,
+				func(v portfolio.AssetViolation) string { return v.Field })
+			return c.Status(httpErr.Status).JSON(httpErr)
+		case errors.As(err, &insufficientErr):
+			log.Warn("transaction exceeds holding", zap.String("user_id", user.Id), zap.Error(err))
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(dto.HTTPError{
+				Status:  fiber.StatusUnprocessableEntity,
+				Error:   "insufficient_holding",
+				Message: err.Error(),
+			})
+		}
+
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to add transaction")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to add transaction")
+		}
+
+		log.Error("failed to add transaction",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.String("symbol", req.Symbol),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(mapper.MapDomainToDTOTransaction(res))
+}
+
+// TransactionReduces — продажа и вывод уменьшают позицию и защищаются как DELETE /portfolio/:id/asset.
+// Неразборчивое тело считается уменьшающим; ошибку формата вернёт сам обработчик.
+func TransactionReduces(c *fiber.Ctx) bool {
+	var body struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(c.Body(), &body); err != nil {
+		return true
+	}
+
+	return portfolio.Transaction{Type: body.Type}.Reduces()
+}
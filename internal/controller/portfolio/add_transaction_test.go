@@ -0,0 +1,40 @@
+package portfolio
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransactionReduces(t *testing.T) {
+	tests := []struct {
+		body string
+		want bool
+	}{
+		{body: `{"type":"buy","symbol":"BTC","amount":1,"price":1}`, want: false},
+		{body: `{"type":"transfer_in","symbol":"BTC","amount":1}`, want: false},
+		{body: `{"type":"sell","symbol":"BTC","amount":1,"price":1}`, want: true},
+		{body: `{"type":"transfer_out","symbol":"BTC","amount":1}`, want: true},
+		{body: `{"type":`, want: true},
+	}
+
+	for _, tt := range tests {
+		var got bool
+		app := fiber.New()
+		app.Post("/portfolio/:id/transactions", func(c *fiber.Ctx) error {
+			got = TransactionReduces(c)
+			return c.SendStatus(fiber.StatusNoContent)
+		})
+
+		req := httptest.NewRequest(fiber.MethodPost, "/portfolio/1/transactions", strings.NewReader(tt.body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		if _, err := app.Test(req); err != nil {
+			t.Fatalf("app.Test: %v", err)
+		}
+
+		if got != tt.want {
+			t.Errorf("TransactionReduces(%s) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
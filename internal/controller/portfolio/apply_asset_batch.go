@@ -0,0 +1,109 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApplyAssetBatch отвечает 200, если все операции применены, 207 при частичном успехе
+// и 409, если в режиме all_or_nothing пакет был отменён.
+func (con PortfolioController) ApplyAssetBatch(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.AssetBatchObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid asset batch", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	ops := make([]portfolio.AssetOperation, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		ops = append(ops, portfolio.AssetOperation{Op: op.Op, Symbol: op.Symbol, Amount: op.Amount})
+	}
+
+	res, err := con.portfolioUsecaseObj.ApplyAssetBatch(ctx, req.PortfolioId, ops, req.AllOrNothing)
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to apply asset batch")
+
+		var validationErr *portfolio.AssetValidationError
+		if errors.As(err, &validationErr) {
+			log.Warn("invalid assets in batch", zap.String("user_id", user.Id), zap.Error(err))
+			httpErr := mapper.MapAssetValidationError(validationErr, binding.SourceBody,
+				func(v portfolio.AssetViolation) string { return fmt.Sprintf("operations[%d].%s", v.Index, v.Field) })
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		if errors.Is(err, portfolio.ErrDuplicateBatchSymbol) || errors.Is(err, portfolio.ErrAssetBatchTooLarge) {
+			httpErr = &dto.HTTPError{
+				Status:  fiber.StatusBadRequest,
+				Error:   "bad_request",
+				Message: err.Error(),
+			}
+		} else if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to apply asset batch")
+		}
+
+		log.Error("failed to apply asset batch",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	statusCode := fiber.StatusOK
+	if res.Failed {
+		log.Warn("asset batch partially failed",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Bool("all_or_nothing", req.AllOrNothing),
+			zap.Bool("rolled_back", res.RolledBack),
+		)
+
+		statusCode = fiber.StatusMultiStatus
+		if req.AllOrNothing {
+			statusCode = fiber.StatusConflict
+		}
+	}
+
+	return c.Status(statusCode).JSON(mapper.MapDomainToDTOAssetBatch(req.PortfolioId, req.AllOrNothing, res))
+}
+
+// AssetBatchHasDeletes — условие для защиты пакета как деструктивного маршрута (step-up, запрет под чужой личностью).
+// Неразборчивое тело считается деструктивным; ошибку формата вернёт сам обработчик.
+func AssetBatchHasDeletes(c *fiber.Ctx) bool {
+	var body struct {
+		Operations []struct {
+			Op string `json:"op"`
+		} `json:"operations"`
+	}
+	if err := json.Unmarshal(c.Body(), &body); err != nil {
+		return true
+	}
+
+	for _, op := range body.Operations {
+		if op.Op != portfolio.AssetOpUpsert {
+			return true
+		}
+	}
+
+	return false
+}
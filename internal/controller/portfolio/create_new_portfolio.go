@@ -1,14 +1,13 @@
 package portfolio
 
 import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
-	"crypto_analyzer-api_gateway/internal/domain/portfolio"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -16,29 +15,15 @@ func (con PortfolioController) CreateNewPortfolio(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	log := logger.FromContext(ctx)
 
-	userVal := c.Locals("user")
-	user, ok := userVal.(*portfolio.User)
-	if !ok || user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
-			Status:  fiber.StatusUnauthorized,
-			Error:   "unauthorized",
-			Message: "user not found in context",
-		})
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	userId := user.Id
-	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("user_id", userId))
-
 	var createPortfolioObj dto.CreatePortfolioObject
-	if err := c.BodyParser(&createPortfolioObj); err != nil {
-		log.Warn("failed to parse portfolio data", zap.Error(err),
-			zap.String("user_id", user.Id))
-
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "wrong portfolio data",
-		}
+	if httpErr := binding.Bind(c, &createPortfolioObj); httpErr != nil {
+		log.Warn("invalid portfolio data", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
@@ -61,7 +46,7 @@ func (con PortfolioController) CreateNewPortfolio(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"user_id":   userId,
+		"user_id":   user.Id,
 		"id":        res.Id,
 		"name":      res.Name,
 		"is_public": res.IsPublic,
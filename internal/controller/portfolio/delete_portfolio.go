@@ -0,0 +1,50 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con PortfolioController) DeletePortfolio(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.PortfolioIdObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid delete portfolio request", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if err := con.portfolioUsecaseObj.DeletePortfolio(ctx, req.PortfolioId); err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to delete portfolio")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to delete portfolio")
+		}
+
+		log.Error("failed to delete portfolio",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	log.Info("portfolio deleted", zap.String("user_id", user.Id), zap.Int("portfolio_id", req.PortfolioId))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "portfolio deleted successfully",
+	})
+}
@@ -1,44 +1,254 @@
 package dto
 
+import "time"
+
 type CreatePortfolioObject struct {
-	Name     string `json:"name"`
+	Name     string `json:"name" validate:"required,max=100"`
 	IsPublic bool   `json:"is_public"`
 }
 
+// UpdatePortfolioObject — частичное обновление: отсутствующие поля не меняются
+type UpdatePortfolioObject struct {
+	PortfolioId int     `path:"id" validate:"required,min=1"`
+	Name        *string `json:"name" validate:"min=1,max=100"`
+	IsPublic    *bool   `json:"is_public"`
+}
+
 type UpsertAssetObject struct {
-	Symbol string  `json:"symbol"`
-	Amount float64 `json:"amount"`
+	PortfolioId int     `path:"id" validate:"required,min=1"`
+	Symbol      string  `json:"symbol" validate:"required,regex=^[A-Za-z0-9]{1,15}$"`
+	Amount      float64 `json:"amount" validate:"min=0"`
+}
+
+type DeleteAssetObject struct {
+	PortfolioId int    `path:"id" validate:"required,min=1"`
+	Symbol      string `query:"symbol" validate:"required,regex=^[A-Za-z0-9]{1,15}$"`
+}
+
+type PortfolioIdObject struct {
+	PortfolioId int `path:"id" validate:"required,min=1"`
+}
+
+type PublicPortfoliosObject struct {
+	Username string `path:"username" validate:"required,max=64,regex=^[A-Za-z0-9_.-]+$"`
 }
 
 type HTTPError struct {
-	Status  int    `json:"status"`
-	Error   string `json:"error"`
+	Status  int          `json:"status"`
+	Error   string       `json:"error"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError — нарушение правила для одного поля запроса
+type FieldError struct {
+	Field   string `json:"field"`
+	Source  string `json:"source"`
 	Message string `json:"message"`
 }
 
 type Portfolio struct {
 	Id       int    `json:"id"`
 	Name     string `json:"name"`
-	IsPublic bool   `json:"isPublic"`
+	IsPublic bool   `json:"is_public"`
 }
 
-type PortfolioHistoryData struct {
-	Id   int `json:"id"`
-	Page int `json:"page"`
-	Size int `json:"size"`
+type PortfolioHistoryQuery struct {
+	Id          int       `path:"id" validate:"required,min=1"`
+	From        time.Time `query:"from"`
+	To          time.Time `query:"to"`
+	Interval    string    `query:"interval" validate:"regex=^(1h|1d|1w)$"`
+	Aggregation string    `query:"aggregation" validate:"regex=^(last|ohlc)$"`
+	Symbols     []string  `query:"symbols" validate:"max=50,regex=^[A-Za-z0-9]{1,15}$"`
+	Cursor      string    `query:"cursor" validate:"max=64"`
+	Limit       int       `query:"limit" validate:"min=1,max=500"`
 }
 
 type PricePoint struct {
-	Timestamp string  `json:"timestamp"`
-	Value     float64 `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
 }
 
+type OHLCPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+}
+
+// PortfolioHistory — для агрегации last заполнен History, для ohlc — OHLC
 type PortfolioHistory struct {
-	History map[string][]PricePoint `json:"history"`
+	Interval    string                  `json:"interval"`
+	Aggregation string                  `json:"aggregation"`
+	History     map[string][]PricePoint `json:"history,omitempty"`
+	OHLC        map[string][]OHLCPoint  `json:"ohlc,omitempty"`
 }
 
 type PublicPortfolio struct {
-	PortfolioId int32              `json:"portfolioId"`
+	PortfolioId int32              `json:"portfolio_id"`
 	Name        string             `json:"name"`
 	Assets      map[string]float64 `json:"assets"`
 }
+
+type PublicProfile struct {
+	UserId      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+}
+
+type AssetProfit struct {
+	Symbol       string  `json:"symbol"`
+	Amount       float64 `json:"amount"`
+	Invested     float64 `json:"invested"`
+	CurrentPrice float64 `json:"current_price"`
+	CurrentValue float64 `json:"current_value"`
+	Profit       float64 `json:"profit"`
+}
+
+type PortfolioProfit struct {
+	PortfolioId   int           `json:"portfolio_id"`
+	Assets        []AssetProfit `json:"assets"`
+	TotalInvested float64       `json:"total_invested"`
+	CurrentValue  float64       `json:"current_value"`
+	Profit        float64       `json:"profit"`
+	ROIPercent    *float64      `json:"roi_percent"`
+	BestAsset     *AssetProfit  `json:"best_asset"`
+	WorstAsset    *AssetProfit  `json:"worst_asset"`
+}
+
+type AssetBatchObject struct {
+	PortfolioId  int                    `path:"id" validate:"required,min=1"`
+	AllOrNothing bool                   `json:"all_or_nothing"`
+	Operations   []AssetOperationObject `json:"operations" validate:"required,max=100"`
+}
+
+type AssetOperationObject struct {
+	Op     string  `json:"op" validate:"required,regex=^(upsert|delete)$"`
+	Symbol string  `json:"symbol" validate:"required,regex=^[A-Za-z0-9]{1,15}$"`
+	Amount float64 `json:"amount" validate:"min=0"`
+}
+
+type AssetOperationResult struct {
+	Index  int        `json:"index"`
+	Op     string     `json:"op"`
+	Symbol string     `json:"symbol"`
+	Status string     `json:"status"`
+	Error  *HTTPError `json:"error,omitempty"`
+}
+
+type AssetBatchResult struct {
+	PortfolioId  int                    `json:"portfolio_id"`
+	AllOrNothing bool                   `json:"all_or_nothing"`
+	RolledBack   bool                   `json:"rolled_back"`
+	Results      []AssetOperationResult `json:"results"`
+}
+
+type ExportObject struct {
+	PortfolioId int    `path:"id" validate:"required,min=1"`
+	Format      string `query:"format" validate:"regex=^(csv|json)$"`
+	Profit      bool   `query:"profit"`
+}
+
+type Holding struct {
+	Symbol       string   `json:"symbol"`
+	Amount       float64  `json:"amount"`
+	Invested     *float64 `json:"invested,omitempty"`
+	CurrentPrice *float64 `json:"current_price,omitempty"`
+	CurrentValue *float64 `json:"current_value,omitempty"`
+	Profit       *float64 `json:"profit,omitempty"`
+}
+
+// ImportObject — файл передаётся полем file в multipart/form-data или телом text/csv
+type ImportObject struct {
+	PortfolioId int    `path:"id" validate:"required,min=1"`
+	DryRun      bool   `query:"dry_run"`
+	Mode        string `query:"mode" validate:"regex=^(merge|replace)$"`
+}
+
+type HoldingChange struct {
+	Symbol string  `json:"symbol"`
+	Action string  `json:"action"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+type ImportResult struct {
+	PortfolioId int               `json:"portfolio_id"`
+	DryRun      bool              `json:"dry_run"`
+	Mode        string            `json:"mode"`
+	Changes     []HoldingChange   `json:"changes"`
+	Unchanged   int               `json:"unchanged"`
+	Batch       *AssetBatchResult `json:"batch,omitempty"`
+}
+
+type PortfolioSummaryStatus struct {
+	PortfolioId  int32      `json:"portfolio_id"`
+	Name         string     `json:"name"`
+	Status       string     `json:"status"`
+	CurrentValue float64    `json:"current_value"`
+	Profit       float64    `json:"profit"`
+	ContentError *HTTPError `json:"content_error,omitempty"`
+	ProfitError  *HTTPError `json:"profit_error,omitempty"`
+}
+
+type SummaryHolding struct {
+	Symbol       string  `json:"symbol"`
+	Amount       float64 `json:"amount"`
+	Invested     float64 `json:"invested"`
+	CurrentValue float64 `json:"current_value"`
+	Profit       float64 `json:"profit"`
+	PortfolioIds []int32 `json:"portfolio_ids"`
+}
+
+type PortfoliosSummary struct {
+	Portfolios    []PortfolioSummaryStatus `json:"portfolios"`
+	Holdings      []SummaryHolding         `json:"holdings"`
+	TotalInvested float64                  `json:"total_invested"`
+	TotalValue    float64                  `json:"total_value"`
+	TotalProfit   float64                  `json:"total_profit"`
+	Partial       bool                     `json:"partial"`
+}
+
+type SymbolSearchObject struct {
+	Query string `query:"q" validate:"max=32"`
+	Limit int    `query:"limit" validate:"min=1,max=100"`
+}
+
+type Symbol struct {
+	Ticker    string   `json:"ticker"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	Precision int      `json:"precision"`
+}
+
+type AddTransactionObject struct {
+	PortfolioId int       `path:"id" validate:"required,min=1"`
+	Type        string    `json:"type" validate:"required,regex=^(buy|sell|transfer_in|transfer_out)$"`
+	Symbol      string    `json:"symbol" validate:"required,regex=^[A-Za-z0-9]{1,15}$"`
+	Amount      float64   `json:"amount" validate:"required,min=0"`
+	Price       float64   `json:"price" validate:"min=0"`
+	Fee         float64   `json:"fee" validate:"min=0"`
+	ExecutedAt  time.Time `json:"executed_at"`
+	Note        string    `json:"note" validate:"max=500"`
+}
+
+type ListTransactionsObject struct {
+	PortfolioId int    `path:"id" validate:"required,min=1"`
+	Symbol      string `query:"symbol" validate:"regex=^[A-Za-z0-9]{1,15}$"`
+	Limit       int    `query:"limit" validate:"min=1,max=200"`
+	Cursor      string `query:"cursor" validate:"max=256"`
+}
+
+type Transaction struct {
+	Id          int64     `json:"id"`
+	PortfolioId int       `json:"portfolio_id"`
+	Type        string    `json:"type"`
+	Symbol      string    `json:"symbol"`
+	Amount      float64   `json:"amount"`
+	Price       float64   `json:"price"`
+	Fee         float64   `json:"fee"`
+	ExecutedAt  time.Time `json:"executed_at"`
+	Note        string    `json:"note,omitempty"`
+}
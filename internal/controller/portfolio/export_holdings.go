@@ -0,0 +1,131 @@
+package portfolio
+
+import (
+	"bytes"
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"strconv"
+)
+
+var (
+	exportColumns       = []string{"symbol", "amount"}
+	exportProfitColumns = []string{"invested", "current_price", "current_value", "profit"}
+)
+
+// ExportHoldings отдаёт позиции портфеля в CSV (по умолчанию) или JSON.
+// Выгрузка собирается в памяти целиком, поэтому ошибки backend приходят обычным HTTPError,
+// а размер ограничен MaxExportHoldings позициями (422 export_too_large).
+func (con PortfolioController) ExportHoldings(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.ExportObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid export request", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+
+	holdings, err := con.portfolioUsecaseObj.GetHoldings(ctx, req.PortfolioId, req.Profit)
+	if err != nil {
+		if errors.Is(err, portfolio.ErrExportTooLarge) {
+			log.Warn("portfolio is too large to export", zap.String("user_id", user.Id),
+				zap.Int("portfolio_id", req.PortfolioId), zap.Error(err))
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(dto.HTTPError{
+				Status:  fiber.StatusUnprocessableEntity,
+				Error:   "export_too_large",
+				Message: err.Error(),
+			})
+		}
+
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to export portfolio")
+
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to export portfolio")
+		}
+
+		log.Error("failed to export portfolio",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	c.Set(fiber.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="portfolio-%d.%s"`, req.PortfolioId, req.Format))
+
+	if req.Format == "json" {
+		dtoHoldings := make([]dto.Holding, 0, len(holdings))
+		for _, h := range holdings {
+			dtoHoldings = append(dtoHoldings, mapper.MapDomainToDTOHolding(h))
+		}
+
+		return c.JSON(dtoHoldings)
+	}
+
+	var buf bytes.Buffer
+	if err := writeHoldingsCSV(&buf, holdings, req.Profit); err != nil {
+		log.Error("failed to write portfolio export", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "internal server error"})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(buf.Bytes())
+}
+
+func writeHoldingsCSV(w io.Writer, holdings []portfolio.Holding, withProfit bool) error {
+	cw := csv.NewWriter(w)
+
+	header := exportColumns
+	if withProfit {
+		header = append(append([]string{}, exportColumns...), exportProfitColumns...)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, h := range holdings {
+		row := []string{h.Symbol, formatAmount(h.Amount)}
+		if withProfit {
+			// Актив без данных о прибыли выгружается с пустыми колонками
+			if p := h.Profit; p != nil {
+				row = append(row, formatAmount(p.Invested), formatAmount(p.CurrentPrice),
+					formatAmount(p.CurrentValue), formatAmount(p.Profit))
+			} else {
+				row = append(row, "", "", "", "")
+			}
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
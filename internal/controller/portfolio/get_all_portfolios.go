@@ -1,14 +1,13 @@
 package portfolio
 
 import (
-	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/binding"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
 	"crypto_analyzer-api_gateway/internal/domain/portfolio"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -16,19 +15,11 @@ func (con PortfolioController) GetAllPortfolios(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	log := logger.FromContext(ctx)
 
-	userVal := c.Locals("user")
-	user, ok := userVal.(*portfolio.User)
-	if !ok || user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
-			Status:  fiber.StatusUnauthorized,
-			Error:   "unauthorized",
-			Message: "user not found in context",
-		})
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	userId := user.Id
-	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("user_id", userId))
-
 	portfolios, err := con.portfolioUsecaseObj.GetAllPortfolios(ctx)
 	if err != nil {
 		httpError := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to get all portfolios")
@@ -37,13 +28,21 @@ func (con PortfolioController) GetAllPortfolios(c *fiber.Ctx) error {
 		}
 
 		log.Error("failed to get all portfolios",
-			zap.String("user_id", userId),
+			zap.String("user_id", user.Id),
 			zap.Error(err))
 
 		return c.Status(httpError.Status).JSON(httpError)
 	}
 
-	portfoliosDTO := mapper.MapPortfolios(portfolios)
+	// API-ключ, ограниченный портфелями, видит только разрешённые
+	allowed := make([]portfolio.Portfolio, 0, len(portfolios))
+	for _, p := range portfolios {
+		if user.CanAccessPortfolio(p.Id) {
+			allowed = append(allowed, p)
+		}
+	}
+
+	portfoliosDTO := mapper.MapPortfolios(allowed)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"portfolios": portfoliosDTO,
@@ -1,14 +1,15 @@
 package portfolio
 
 import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
 	"crypto_analyzer-api_gateway/internal/domain/portfolio"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -16,81 +17,72 @@ func (con PortfolioController) GetPortfolioHistory(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	log := logger.FromContext(ctx)
 
-	userVal := c.Locals("user")
-	user, ok := userVal.(*portfolio.User)
-	if !ok || user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
-			Status:  fiber.StatusUnauthorized,
-			Error:   "unauthorized",
-			Message: "user not found in context",
-		})
-	}
-
-	userId := user.Id
-
-	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("user_id", userId))
-
-	var portfolioHistoryData dto.PortfolioHistoryData
-	if err := c.BodyParser(&portfolioHistoryData); err != nil {
-		log.Warn("failed to parse portfolio history request data", zap.Error(err))
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "wrong portfolio history request data",
-		}
-		return c.Status(httpErr.Status).JSON(httpErr)
-	}
-
-	portfolioId := portfolioHistoryData.Id
-	if portfolioId == 0 {
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "portfolio_id is required",
-		}
-		return c.Status(httpErr.Status).JSON(httpErr)
-	}
-
-	size := portfolioHistoryData.Size
-	if size == 0 {
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "size is required",
-		}
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	page := portfolioHistoryData.Page
-	if page == 0 {
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "page is required",
-		}
+	var query dto.PortfolioHistoryQuery
+	if httpErr := binding.Bind(c, &query); httpErr != nil {
+		log.Warn("invalid portfolio history request", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	res, err := con.portfolioUsecaseObj.GetPortfolioHistory(ctx, int32(portfolioId), int32(page), int32(size))
+	res, err := con.portfolioUsecaseObj.GetPortfolioHistory(ctx, portfolio.HistoryRequest{
+		PortfolioId: query.Id,
+		From:        query.From,
+		To:          query.To,
+		Interval:    query.Interval,
+		Aggregation: query.Aggregation,
+		Symbols:     query.Symbols,
+		Cursor:      query.Cursor,
+		Limit:       query.Limit,
+	})
 	if err != nil {
 		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to get portfolio history")
 
-		if st, ok := status.FromError(err); ok {
-			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to get portfolio history")
+		switch {
+		case errors.Is(err, portfolio.ErrInvalidHistoryRange):
+			httpErr = &dto.HTTPError{
+				Status:  fiber.StatusBadRequest,
+				Error:   "bad_request",
+				Message: "from must be before to",
+			}
+		case errors.Is(err, portfolio.ErrInvalidCursor):
+			httpErr = &dto.HTTPError{
+				Status:  fiber.StatusBadRequest,
+				Error:   "bad_request",
+				Message: "cursor does not match the requested range",
+			}
+		case errors.Is(err, portfolio.ErrHistoryTooLarge):
+			httpErr = &dto.HTTPError{
+				Status:  fiber.StatusBadRequest,
+				Error:   "bad_request",
+				Message: "history window is too large, use a smaller limit or a wider interval",
+			}
+		default:
+			if st, ok := status.FromError(err); ok {
+				httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to get portfolio history")
+			}
 		}
 
 		log.Error("failed to get portfolio history",
-			zap.String("user_id", userId),
-			zap.Int("portfolio_id", portfolioId),
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", query.Id),
 			zap.Error(err),
 		)
 
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	history := mapper.MapDomainToDTOHistory(res)
+	var nextCursor *string
+	if res.NextCursor != "" {
+		nextCursor = &res.NextCursor
+	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"portfolio_history": history,
+		"portfolio_history": mapper.MapDomainToDTOHistory(res),
+		"next_cursor":       nextCursor,
 	})
 }
@@ -0,0 +1,40 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPortfoliosSummary отвечает 200 и при частичных сбоях: такие портфели помечены в статусе,
+// а в ответе стоит partial=true.
+func (con PortfolioController) GetPortfoliosSummary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	// API-ключ, ограниченный портфелями, видит сводку только по разрешённым
+	summary, err := con.portfolioUsecaseObj.GetPortfoliosSummary(ctx, user.CanAccessPortfolio)
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to get portfolios summary")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to get portfolios summary")
+		}
+
+		log.Error("failed to get portfolios summary",
+			zap.String("user_id", user.Id),
+			zap.Error(err))
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mapper.MapDomainToDTOSummary(summary))
+}
@@ -1,42 +1,33 @@
 package portfolio
 
 import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"strconv"
 )
 
 func (con PortfolioController) GetPublicPortfolios(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	log := logger.FromContext(ctx)
 
-	userID := c.Params("user_id")
-	if userID == "" {
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "user_id is required",
-		}
+	var req dto.PublicPortfoliosObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid public portfolios request", zap.Any("details", httpErr.Details))
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	userIDInt, err := strconv.Atoi(userID)
-	if err != nil {
-		log.Warn("failed to convert user_id", zap.Error(err))
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "wrong user_id",
-		}
+	res, err := con.portfolioUsecaseObj.GetPublicPortfolios(ctx, req.Username)
+	if errors.Is(err, portfolio.ErrUserNotFound) {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.NotFound, "user not found")
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
-
-	res, err := con.portfolioUsecaseObj.GetPublicPortfolios(ctx, userIDInt)
 	if err != nil {
 		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to get public portfolios")
 
@@ -45,17 +36,23 @@ func (con PortfolioController) GetPublicPortfolios(c *fiber.Ctx) error {
 		}
 
 		log.Error("failed to get public portfolios",
-			zap.String("user_id", userID),
+			zap.String("username", req.Username),
 			zap.Error(err),
 		)
 
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	publicPortfolios := mapper.MapPublicPortfolios(res)
+	publicPortfolios := mapper.MapPublicPortfolios(res.Portfolios)
+
+	// Зритель определяется AuthOptional; анонимный запрос владельцем не считается
+	viewer, _ := c.Locals("user").(*portfolio.User)
+	isOwner := viewer != nil && viewer.Id == res.Owner.UserId
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"user_id":           userID,
+		"user_id":           res.Owner.UserId,
+		"owner":             mapper.MapPublicProfile(res.Owner),
+		"is_owner":          isOwner,
 		"public_portfolios": publicPortfolios,
 	})
 }
@@ -0,0 +1,254 @@
+package portfolio
+
+import (
+	"bytes"
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxImportRows      = 1000
+	maxImportFileBytes = 1 << 20
+)
+
+var importSymbolPattern = regexp.MustCompile(`^[A-Za-z0-9]{1,15}$`)
+
+// ImportHoldings принимает CSV с колонками symbol и amount (остальные колонки игнорируются,
+// поэтому подходит файл из /export). С dry_run=true только возвращает разницу с портфелем.
+func (con PortfolioController) ImportHoldings(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.ImportObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid import request", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+	if req.Mode == "" {
+		req.Mode = "merge"
+	}
+
+	file, err := importFile(c)
+	if err != nil {
+		log.Warn("failed to read import file", zap.String("user_id", user.Id), zap.Error(err))
+		return c.Status(fiber.StatusBadRequest).JSON(dto.HTTPError{
+			Status:  fiber.StatusBadRequest,
+			Error:   "bad_request",
+			Message: err.Error(),
+		})
+	}
+
+	holdings, lines, violations := parseHoldingsCSV(file)
+	if len(violations) > 0 {
+		log.Warn("invalid import file", zap.String("user_id", user.Id), zap.Any("details", violations))
+		return c.Status(fiber.StatusBadRequest).JSON(dto.HTTPError{
+			Status:  fiber.StatusBadRequest,
+			Error:   "bad_request",
+			Message: "import file validation failed",
+			Details: violations,
+		})
+	}
+
+	res, err := con.portfolioUsecaseObj.ImportHoldings(ctx, req.PortfolioId, holdings, req.Mode == "replace", req.DryRun)
+	if err != nil {
+		var validationErr *portfolio.AssetValidationError
+		if errors.As(err, &validationErr) {
+			log.Warn("invalid assets in import file", zap.String("user_id", user.Id), zap.Error(err))
+			httpErr := mapper.MapAssetValidationError(validationErr, "file",
+				func(v portfolio.AssetViolation) string { return fmt.Sprintf("rows[%d].%s", lines[v.Index], v.Field) })
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		if errors.Is(err, portfolio.ErrAssetBatchTooLarge) {
+			log.Warn("import diff is too large", zap.String("user_id", user.Id), zap.Error(err))
+			return c.Status(fiber.StatusBadRequest).JSON(dto.HTTPError{
+				Status:  fiber.StatusBadRequest,
+				Error:   "bad_request",
+				Message: err.Error(),
+			})
+		}
+
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to import portfolio")
+
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to import portfolio")
+		}
+
+		log.Error("failed to import portfolio",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	statusCode := fiber.StatusOK
+	if res.Batch != nil && res.Batch.Failed {
+		log.Warn("portfolio import rolled back",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Bool("rolled_back", res.Batch.RolledBack),
+		)
+		statusCode = fiber.StatusConflict
+	}
+
+	return c.Status(statusCode).JSON(mapper.MapDomainToDTOImport(req.PortfolioId, req.DryRun, req.Mode, res))
+}
+
+// ImportApplies — импорт без dry_run меняет портфель и защищается как деструктивный маршрут.
+func ImportApplies(c *fiber.Ctx) bool {
+	dryRun, err := strconv.ParseBool(c.Query("dry_run"))
+	return err != nil || !dryRun
+}
+
+// importFile берёт файл из поля file multipart-формы или, для text/csv, из тела.
+func importFile(c *fiber.Ctx) ([]byte, error) {
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, errors.New("file field is required")
+		}
+		if header.Size > maxImportFileBytes {
+			return nil, fmt.Errorf("file is larger than %d bytes", maxImportFileBytes)
+		}
+
+		f, err := header.Open()
+		if err != nil {
+			return nil, errors.New("failed to open uploaded file")
+		}
+		defer f.Close()
+
+		return io.ReadAll(io.LimitReader(f, maxImportFileBytes))
+	}
+
+	body := c.Body()
+	if len(body) == 0 {
+		return nil, errors.New("csv file is required")
+	}
+	if len(body) > maxImportFileBytes {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxImportFileBytes)
+	}
+
+	return body, nil
+}
+
+// parseHoldingsCSV собирает все ошибки файла; строки адресуются по номеру строки в файле (заголовок — 1).
+// lines[i] — номер строки файла для holdings[i].
+func parseHoldingsCSV(file []byte) ([]portfolio.Holding, []int, []dto.FieldError) {
+	r := csv.NewReader(bytes.NewReader(file))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, []dto.FieldError{{Field: "header", Source: "file", Message: "missing csv header"}}
+	}
+
+	symbolCol, amountCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "symbol":
+			symbolCol = i
+		case "amount":
+			amountCol = i
+		}
+	}
+	if symbolCol < 0 || amountCol < 0 {
+		return nil, nil, []dto.FieldError{{Field: "header", Source: "file", Message: "csv must have symbol and amount columns"}}
+	}
+
+	var (
+		holdings   []portfolio.Holding
+		lines      []int
+		violations []dto.FieldError
+		seen       = make(map[string]int)
+	)
+
+	for rows := 1; ; rows++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if rows > maxImportRows {
+			return nil, nil, []dto.FieldError{{Field: "file", Source: "file",
+				Message: fmt.Sprintf("must have at most %d rows", maxImportRows)}}
+		}
+
+		// Номер строки файла, а не записи: пустые строки и многострочные поля в кавычках его сдвигают
+		var (
+			line     int
+			parseErr *csv.ParseError
+		)
+		switch {
+		case err == nil:
+			line, _ = r.FieldPos(0)
+		case errors.As(err, &parseErr):
+			line = parseErr.StartLine
+		}
+
+		field := func(name string) string { return fmt.Sprintf("rows[%d].%s", line, name) }
+
+		if err != nil {
+			violations = append(violations, dto.FieldError{Field: field("row"), Source: "file", Message: "malformed csv row"})
+			continue
+		}
+		if max(symbolCol, amountCol) >= len(record) {
+			violations = append(violations, dto.FieldError{Field: field("row"), Source: "file", Message: "missing columns"})
+			continue
+		}
+
+		symbol := strings.TrimSpace(record[symbolCol])
+		rowOk := true
+
+		if !importSymbolPattern.MatchString(symbol) {
+			violations = append(violations, dto.FieldError{Field: field("symbol"), Source: "file",
+				Message: "must match " + importSymbolPattern.String()})
+			rowOk = false
+		} else if first, dup := seen[strings.ToUpper(symbol)]; dup {
+			violations = append(violations, dto.FieldError{Field: field("symbol"), Source: "file",
+				Message: fmt.Sprintf("duplicates row %d", first)})
+			rowOk = false
+		} else {
+			seen[strings.ToUpper(symbol)] = line
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(record[amountCol]), 64)
+		if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+			violations = append(violations, dto.FieldError{Field: field("amount"), Source: "file", Message: "must be a number"})
+			rowOk = false
+		} else if amount < 0 {
+			violations = append(violations, dto.FieldError{Field: field("amount"), Source: "file", Message: "must be at least 0"})
+			rowOk = false
+		}
+
+		if rowOk {
+			holdings = append(holdings, portfolio.Holding{Symbol: symbol, Amount: amount})
+			lines = append(lines, line)
+		}
+	}
+
+	return holdings, lines, violations
+}
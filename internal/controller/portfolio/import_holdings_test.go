@@ -0,0 +1,101 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHoldingsCSV(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           string
+		wantHoldings   []portfolio.Holding
+		wantLines      []int
+		wantViolations []dto.FieldError
+	}{
+		{
+			name:         "valid",
+			file:         "symbol,amount\nBTC,0.5\neth, 2\n",
+			wantHoldings: []portfolio.Holding{{Symbol: "BTC", Amount: 0.5}, {Symbol: "eth", Amount: 2}},
+			wantLines:    []int{2, 3},
+		},
+		{
+			name:         "columns in any order with extra columns and BOM",
+			file:         "\ufeffnote,Amount,SYMBOL\ncold wallet,1.5,BTC\n",
+			wantHoldings: []portfolio.Holding{{Symbol: "BTC", Amount: 1.5}},
+			wantLines:    []int{2},
+		},
+		{
+			name:         "blank lines keep file line numbers",
+			file:         "symbol,amount\n\nBTC,1\n\n\nETH,x\nSOL,3\n",
+			wantHoldings: []portfolio.Holding{{Symbol: "BTC", Amount: 1}, {Symbol: "SOL", Amount: 3}},
+			wantLines:    []int{3, 7},
+			wantViolations: []dto.FieldError{
+				{Field: "rows[6].amount", Source: "file", Message: "must be a number"},
+			},
+		},
+		{
+			name:         "multiline quoted field shifts following lines",
+			file:         "symbol,amount,note\nBTC,1,\"first\nsecond\"\nETH,-1,\n",
+			wantHoldings: []portfolio.Holding{{Symbol: "BTC", Amount: 1}},
+			wantLines:    []int{2},
+			wantViolations: []dto.FieldError{
+				{Field: "rows[4].amount", Source: "file", Message: "must be at least 0"},
+			},
+		},
+		{
+			name:         "all row errors are collected",
+			file:         "symbol,amount\nBTC,1\nB-TC,1\nbtc,2\nETH\nSOL,NaN\nADA,\"1\n",
+			wantHoldings: []portfolio.Holding{{Symbol: "BTC", Amount: 1}},
+			wantLines:    []int{2},
+			wantViolations: []dto.FieldError{
+				{Field: "rows[3].symbol", Source: "file", Message: "must match ^[A-Za-z0-9]{1,15}$"},
+				{Field: "rows[4].symbol", Source: "file", Message: "duplicates row 2"},
+				{Field: "rows[5].row", Source: "file", Message: "missing columns"},
+				{Field: "rows[6].amount", Source: "file", Message: "must be a number"},
+				{Field: "rows[7].row", Source: "file", Message: "malformed csv row"},
+			},
+		},
+		{
+			name: "empty file",
+			file: "",
+			wantViolations: []dto.FieldError{
+				{Field: "header", Source: "file", Message: "missing csv header"},
+			},
+		},
+		{
+			name: "missing amount column",
+			file: "symbol,qty\nBTC,1\n",
+			wantViolations: []dto.FieldError{
+				{Field: "header", Source: "file", Message: "csv must have symbol and amount columns"},
+			},
+		},
+		{
+			name: "too many rows",
+			file: "symbol,amount\n" + strings.Repeat("BTC,1\n", maxImportRows+1),
+			wantViolations: []dto.FieldError{
+				{Field: "file", Source: "file", Message: fmt.Sprintf("must have at most %d rows", maxImportRows)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdings, lines, violations := parseHoldingsCSV([]byte(tt.file))
+
+			if !reflect.DeepEqual(holdings, tt.wantHoldings) {
+				t.Errorf("holdings = %+v, want %+v", holdings, tt.wantHoldings)
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+			}
+			if !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("violations = %+v, want %+v", violations, tt.wantViolations)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con PortfolioController) ListTransactions(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.ListTransactionsObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid transactions request", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	res, err := con.portfolioUsecaseObj.ListTransactions(ctx, portfolio.TransactionQuery{
+		PortfolioId: req.PortfolioId,
+		Symbol:      req.Symbol,
+		PageSize:    req.Limit,
+		Cursor:      req.Cursor,
+	})
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to list transactions")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to list transactions")
+		}
+
+		log.Error("failed to list transactions",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var nextCursor *string
+	if res.NextCursor != "" {
+		nextCursor = &res.NextCursor
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"transactions": mapper.MapDomainToDTOTransactions(res.Transactions),
+		"next_cursor":  nextCursor,
+	})
+}
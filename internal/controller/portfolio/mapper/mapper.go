@@ -5,36 +5,37 @@ import (
 	"crypto_analyzer-api_gateway/internal/domain/portfolio"
 	"github.com/gofiber/fiber/v2"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func GrpcCodeToHTTPError(code codes.Code, msg string) *dto.HTTPError {
 	switch code {
 	case codes.Canceled:
-		return &dto.HTTPError{fiber.StatusRequestTimeout, "canceled", "request was canceled"}
+		return &dto.HTTPError{Status: fiber.StatusRequestTimeout, Error: "canceled", Message: "request was canceled"}
 	case codes.Unknown, codes.Internal, codes.DataLoss:
-		return &dto.HTTPError{fiber.StatusInternalServerError, "internal_error", "internal server error"}
+		return &dto.HTTPError{Status: fiber.StatusInternalServerError, Error: "internal_error", Message: "internal server error"}
 	case codes.InvalidArgument, codes.OutOfRange:
-		return &dto.HTTPError{fiber.StatusBadRequest, "invalid_argument", msg}
+		return &dto.HTTPError{Status: fiber.StatusBadRequest, Error: "invalid_argument", Message: msg}
 	case codes.DeadlineExceeded:
-		return &dto.HTTPError{fiber.StatusGatewayTimeout, "timeout", "request timeout"}
+		return &dto.HTTPError{Status: fiber.StatusGatewayTimeout, Error: "timeout", Message: "request timeout"}
 	case codes.NotFound:
-		return &dto.HTTPError{fiber.StatusNotFound, "not_found", msg}
+		return &dto.HTTPError{Status: fiber.StatusNotFound, Error: "not_found", Message: msg}
 	case codes.AlreadyExists, codes.Aborted:
-		return &dto.HTTPError{fiber.StatusConflict, "conflict", msg}
+		return &dto.HTTPError{Status: fiber.StatusConflict, Error: "conflict", Message: msg}
 	case codes.PermissionDenied:
-		return &dto.HTTPError{fiber.StatusForbidden, "forbidden", msg}
+		return &dto.HTTPError{Status: fiber.StatusForbidden, Error: "forbidden", Message: msg}
 	case codes.ResourceExhausted:
-		return &dto.HTTPError{fiber.StatusTooManyRequests, "rate_limit", "rate limit exceeded"}
+		return &dto.HTTPError{Status: fiber.StatusTooManyRequests, Error: "rate_limit", Message: "rate limit exceeded"}
 	case codes.FailedPrecondition:
-		return &dto.HTTPError{fiber.StatusPreconditionFailed, "failed_precondition", msg}
+		return &dto.HTTPError{Status: fiber.StatusPreconditionFailed, Error: "failed_precondition", Message: msg}
 	case codes.Unimplemented:
-		return &dto.HTTPError{fiber.StatusNotImplemented, "not_implemented", msg}
+		return &dto.HTTPError{Status: fiber.StatusNotImplemented, Error: "not_implemented", Message: msg}
 	case codes.Unavailable:
-		return &dto.HTTPError{fiber.StatusServiceUnavailable, "unavailable", "service unavailable"}
+		return &dto.HTTPError{Status: fiber.StatusServiceUnavailable, Error: "unavailable", Message: "service unavailable"}
 	case codes.Unauthenticated:
-		return &dto.HTTPError{fiber.StatusUnauthorized, "unauthenticated", msg}
+		return &dto.HTTPError{Status: fiber.StatusUnauthorized, Error: "unauthenticated", Message: msg}
 	default:
-		return &dto.HTTPError{fiber.StatusInternalServerError, "unexpected_error", "unexpected error"}
+		return &dto.HTTPError{Status: fiber.StatusInternalServerError, Error: "unexpected_error", Message: "unexpected error"}
 	}
 }
 
@@ -52,19 +53,37 @@ func MapPortfolios(portfolios []portfolio.Portfolio) []dto.Portfolio {
 	return portfoliosSlice
 }
 
-func MapDomainToDTOHistory(portfolioHistory portfolio.PortfolioHistory) dto.PortfolioHistory {
-	history := dto.PortfolioHistory{History: make(map[string][]dto.PricePoint, len(portfolioHistory.History))}
+func MapDomainToDTOHistory(portfolioHistory portfolio.AggregatedHistory) dto.PortfolioHistory {
+	history := dto.PortfolioHistory{
+		Interval:    portfolioHistory.Interval,
+		Aggregation: portfolioHistory.Aggregation,
+	}
 
-	for key, v := range portfolioHistory.History {
-		if v == nil {
-			continue
+	if portfolioHistory.Aggregation == portfolio.HistoryAggregationOHLC {
+		history.OHLC = make(map[string][]dto.OHLCPoint, len(portfolioHistory.Buckets))
+		for key, v := range portfolioHistory.Buckets {
+			history.OHLC[key] = make([]dto.OHLCPoint, 0, len(v))
+			for _, b := range v {
+				history.OHLC[key] = append(history.OHLC[key], dto.OHLCPoint{
+					Timestamp: b.Start,
+					Open:      b.Open,
+					High:      b.High,
+					Low:       b.Low,
+					Close:     b.Close,
+				})
+			}
 		}
 
+		return history
+	}
+
+	history.History = make(map[string][]dto.PricePoint, len(portfolioHistory.Buckets))
+	for key, v := range portfolioHistory.Buckets {
 		history.History[key] = make([]dto.PricePoint, 0, len(v))
-		for _, m := range v {
+		for _, b := range v {
 			history.History[key] = append(history.History[key], dto.PricePoint{
-				Timestamp: m.Timestamp,
-				Value:     m.Value,
+				Timestamp: b.Start,
+				Value:     b.Close,
 			})
 		}
 	}
@@ -85,3 +104,215 @@ func MapPublicPortfolios(portfolios []portfolio.PublicPortfolio) []dto.PublicPor
 
 	return publicPortfolios
 }
+
+func MapPublicProfile(profile portfolio.PublicProfile) dto.PublicProfile {
+	return dto.PublicProfile{
+		UserId:      profile.UserId,
+		Username:    profile.Username,
+		DisplayName: profile.DisplayName,
+		AvatarURL:   profile.AvatarURL,
+	}
+}
+
+func mapAssetProfit(asset portfolio.AssetProfit) dto.AssetProfit {
+	return dto.AssetProfit{
+		Symbol:       asset.Symbol,
+		Amount:       asset.Amount,
+		Invested:     asset.Invested,
+		CurrentPrice: asset.CurrentPrice,
+		CurrentValue: asset.CurrentValue,
+		Profit:       asset.Profit,
+	}
+}
+
+func MapDomainToDTOProfit(portfolioId int, profit portfolio.PortfolioProfit) dto.PortfolioProfit {
+	res := dto.PortfolioProfit{
+		PortfolioId:   portfolioId,
+		Assets:        make([]dto.AssetProfit, 0, len(profit.Assets)),
+		TotalInvested: profit.TotalInvested,
+		CurrentValue:  profit.CurrentValue,
+		Profit:        profit.Profit,
+		ROIPercent:    profit.ROIPercent,
+	}
+
+	for _, v := range profit.Assets {
+		res.Assets = append(res.Assets, mapAssetProfit(v))
+	}
+
+	if profit.Best != nil {
+		best := mapAssetProfit(*profit.Best)
+		res.BestAsset = &best
+	}
+	if profit.Worst != nil {
+		worst := mapAssetProfit(*profit.Worst)
+		res.WorstAsset = &worst
+	}
+
+	return res
+}
+
+func MapDomainToDTOAssetBatch(portfolioId int, allOrNothing bool, res portfolio.AssetBatchResult) dto.AssetBatchResult {
+	results := make([]dto.AssetOperationResult, 0, len(res.Results))
+
+	for i, r := range res.Results {
+		item := dto.AssetOperationResult{
+			Index:  i,
+			Op:     r.Operation.Op,
+			Symbol: r.Operation.Symbol,
+			Status: r.Status,
+		}
+
+		item.Error = errorToHTTPError(r.Err, "failed to "+r.Operation.Op+" asset")
+
+		results = append(results, item)
+	}
+
+	return dto.AssetBatchResult{
+		PortfolioId:  portfolioId,
+		AllOrNothing: allOrNothing,
+		RolledBack:   res.RolledBack,
+		Results:      results,
+	}
+}
+
+func MapDomainToDTOHolding(h portfolio.Holding) dto.Holding {
+	holding := dto.Holding{Symbol: h.Symbol, Amount: h.Amount}
+
+	if h.Profit != nil {
+		holding.Invested = &h.Profit.Invested
+		holding.CurrentPrice = &h.Profit.CurrentPrice
+		holding.CurrentValue = &h.Profit.CurrentValue
+		holding.Profit = &h.Profit.Profit
+	}
+
+	return holding
+}
+
+func MapDomainToDTOImport(portfolioId int, dryRun bool, mode string, res portfolio.ImportResult) dto.ImportResult {
+	changes := make([]dto.HoldingChange, 0, len(res.Changes))
+	for _, c := range res.Changes {
+		changes = append(changes, dto.HoldingChange{
+			Symbol: c.Symbol,
+			Action: c.Action,
+			Before: c.Before,
+			After:  c.After,
+		})
+	}
+
+	result := dto.ImportResult{
+		PortfolioId: portfolioId,
+		DryRun:      dryRun,
+		Mode:        mode,
+		Changes:     changes,
+		Unchanged:   res.Unchanged,
+	}
+
+	if res.Batch != nil {
+		batch := MapDomainToDTOAssetBatch(portfolioId, true, *res.Batch)
+		result.Batch = &batch
+	}
+
+	return result
+}
+
+func MapDomainToDTOSummary(summary portfolio.PortfoliosSummary) dto.PortfoliosSummary {
+	res := dto.PortfoliosSummary{
+		Portfolios:    make([]dto.PortfolioSummaryStatus, 0, len(summary.Portfolios)),
+		Holdings:      make([]dto.SummaryHolding, 0, len(summary.Holdings)),
+		TotalInvested: summary.TotalInvested,
+		TotalValue:    summary.TotalValue,
+		TotalProfit:   summary.TotalProfit,
+		Partial:       summary.Partial,
+	}
+
+	for _, p := range summary.Portfolios {
+		res.Portfolios = append(res.Portfolios, dto.PortfolioSummaryStatus{
+			PortfolioId:  p.Portfolio.Id,
+			Name:         p.Portfolio.Name,
+			Status:       p.Status,
+			CurrentValue: p.CurrentValue,
+			Profit:       p.Profit,
+			ContentError: errorToHTTPError(p.ContentErr, "failed to get portfolio content"),
+			ProfitError:  errorToHTTPError(p.ProfitErr, "failed to get portfolio profit"),
+		})
+	}
+
+	for _, h := range summary.Holdings {
+		res.Holdings = append(res.Holdings, dto.SummaryHolding{
+			Symbol:       h.Symbol,
+			Amount:       h.Amount,
+			Invested:     h.Invested,
+			CurrentValue: h.CurrentValue,
+			Profit:       h.Profit,
+			PortfolioIds: h.PortfolioIds,
+		})
+	}
+
+	return res
+}
+
+func errorToHTTPError(err error, msg string) *dto.HTTPError {
+	if err == nil {
+		return nil
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return GrpcCodeToHTTPError(st.Code(), msg)
+	}
+
+	return GrpcCodeToHTTPError(codes.Unknown, msg)
+}
+
+// MapAssetValidationError переводит нарушения по активам в 400 с адресом поля в терминах запроса.
+func MapAssetValidationError(err *portfolio.AssetValidationError, source string,
+	field func(v portfolio.AssetViolation) string) *dto.HTTPError {
+	details := make([]dto.FieldError, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		details = append(details, dto.FieldError{Field: field(v), Source: source, Message: v.Message})
+	}
+
+	return &dto.HTTPError{
+		Status:  fiber.StatusBadRequest,
+		Error:   "bad_request",
+		Message: "request validation failed",
+		Details: details,
+	}
+}
+
+func MapSymbols(symbols []portfolio.Symbol) []dto.Symbol {
+	res := make([]dto.Symbol, 0, len(symbols))
+
+	for _, s := range symbols {
+		res = append(res, dto.Symbol{
+			Ticker:    s.Ticker,
+			Name:      s.Name,
+			Aliases:   s.Aliases,
+			Precision: s.Precision,
+		})
+	}
+
+	return res
+}
+
+func MapDomainToDTOTransaction(tx portfolio.Transaction) dto.Transaction {
+	return dto.Transaction{
+		Id:          tx.Id,
+		PortfolioId: tx.PortfolioId,
+		Type:        tx.Type,
+		Symbol:      tx.Symbol,
+		Amount:      tx.Amount,
+		Price:       tx.Price,
+		Fee:         tx.Fee,
+		ExecutedAt:  tx.ExecutedAt,
+		Note:        tx.Note,
+	}
+}
+
+func MapDomainToDTOTransactions(txs []portfolio.Transaction) []dto.Transaction {
+	res := make([]dto.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		res = append(res, MapDomainToDTOTransaction(tx))
+	}
+
+	return res
+}
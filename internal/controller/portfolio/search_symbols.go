@@ -0,0 +1,33 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const defaultSymbolSearchLimit = 20
+
+// SearchSymbols ищет активы реестра по префиксу тикера, псевдонима или названия.
+// Без q возвращает первые limit активов по алфавиту.
+func (con PortfolioController) SearchSymbols(c *fiber.Ctx) error {
+	log := logger.FromContext(c.UserContext())
+
+	var req dto.SymbolSearchObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid symbol search request", zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultSymbolSearchLimit
+	}
+
+	symbols := con.portfolioUsecaseObj.SearchSymbols(req.Query, req.Limit)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"symbols": mapper.MapSymbols(symbols),
+	})
+}
@@ -0,0 +1,79 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con PortfolioController) UpdatePortfolio(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var req dto.UpdatePortfolioObject
+	if httpErr := binding.Bind(c, &req); httpErr != nil {
+		log.Warn("invalid portfolio update", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if req.Name == nil && req.IsPublic == nil {
+		httpErr := &dto.HTTPError{
+			Status:  fiber.StatusBadRequest,
+			Error:   "bad_request",
+			Message: "nothing to update, set name or is_public",
+		}
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	res, err := con.portfolioUsecaseObj.UpdatePortfolio(ctx, req.PortfolioId, portfolio.PortfolioUpdate{
+		Name:     req.Name,
+		IsPublic: req.IsPublic,
+	})
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to update portfolio")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to update portfolio")
+		}
+
+		log.Error("failed to update portfolio",
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", req.PortfolioId),
+			zap.Error(err),
+		)
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user_id":   user.Id,
+		"id":        res.Id,
+		"name":      res.Name,
+		"is_public": res.IsPublic,
+	})
+}
+
+// UpdateChangesVisibility — тело с is_public может опубликовать чужие позиции, поэтому такое изменение
+// запрещено под чужой личностью. Неразборчивое тело считается меняющим видимость.
+func UpdateChangesVisibility(c *fiber.Ctx) bool {
+	var body struct {
+		IsPublic json.RawMessage `json:"is_public"`
+	}
+	if err := json.Unmarshal(c.Body(), &body); err != nil {
+		return true
+	}
+
+	return body.IsPublic != nil
+}
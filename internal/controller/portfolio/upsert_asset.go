@@ -1,81 +1,55 @@
 package portfolio
 
 import (
+	"crypto_analyzer-api_gateway/internal/controller/binding"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
 	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
 	"crypto_analyzer-api_gateway/internal/domain/portfolio"
 	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"strconv"
 )
 
 func (con PortfolioController) UpsertAsset(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	log := logger.FromContext(ctx)
 
-	userVal := c.Locals("user")
-	user, ok := userVal.(*portfolio.User)
-	if !ok || user == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
-			Status:  fiber.StatusUnauthorized,
-			Error:   "unauthorized",
-			Message: "user not found in context",
-		})
+	user, httpErr := binding.User(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	userId := user.Id
-
-	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("user_id", userId))
-
 	var upsertAssetObj dto.UpsertAssetObject
-	if err := c.BodyParser(&upsertAssetObj); err != nil {
-		log.Warn("failed to parse upsert data", zap.Error(err))
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "wrong upsert data",
-		}
+	if httpErr := binding.Bind(c, &upsertAssetObj); httpErr != nil {
+		log.Warn("invalid upsert data", zap.String("user_id", user.Id),
+			zap.Any("details", httpErr.Details))
 		return c.Status(httpErr.Status).JSON(httpErr)
 	}
 
-	portfolioId := c.Params("portfolio_id")
-	if portfolioId == "" {
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "portfolio_id is required",
-		}
-		return c.Status(httpErr.Status).JSON(httpErr)
-	}
+	portfolioId := upsertAssetObj.PortfolioId
+	symbol := upsertAssetObj.Symbol
 
-	portfolioIdInt, err := strconv.Atoi(portfolioId)
+	err := con.portfolioUsecaseObj.UpsertAsset(ctx, portfolioId, symbol, upsertAssetObj.Amount)
 	if err != nil {
-		log.Warn("failed to convert portfolio_id", zap.Error(err))
-		httpErr := &dto.HTTPError{
-			Status:  fiber.StatusBadRequest,
-			Error:   "bad_request",
-			Message: "wrong portfolio_id",
+		var validationErr *portfolio.AssetValidationError
+		if errors.As(err, &validationErr) {
+			log.Warn("invalid asset", zap.String("user_id", user.Id), zap.String("symbol", symbol), zap.Error(err))
+			httpErr := mapper.MapAssetValidationError(validationErr, binding.SourceBody,
+				func(v portfolio.AssetViolation) string { return v.Field })
+			return c.Status(httpErr.Status).JSON(httpErr)
 		}
-		return c.Status(httpErr.Status).JSON(httpErr)
-	}
 
-	amount := upsertAssetObj.Amount
-	symbol := upsertAssetObj.Symbol
-
-	err = con.portfolioUsecaseObj.UpsertAsset(ctx, portfolioIdInt, symbol, amount)
-	if err != nil {
 		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to upsert asset")
 		if st, ok := status.FromError(err); ok {
 			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to upsert asset")
 		}
 
 		log.Error("failed to upsert asset",
-			zap.String("user_id", userId),
-			zap.String("portfolio_id", portfolioId),
+			zap.String("user_id", user.Id),
+			zap.Int("portfolio_id", portfolioId),
 			zap.String("symbol", symbol),
 			zap.Error(err),
 		)
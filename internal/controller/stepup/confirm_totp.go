@@ -0,0 +1,47 @@
+package stepup
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con StepUpController) ConfirmTOTP(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	code, httpErr := parseCode(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	if err := con.stepUpUsecaseObj.ConfirmTOTP(ctx, code); err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to confirm totp")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "invalid code")
+		}
+
+		log.Warn("failed to confirm totp", zap.String("user_id", user.Id), zap.Error(err))
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	log.Info("totp enabled", zap.String("user_id", user.Id))
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
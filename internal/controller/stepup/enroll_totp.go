@@ -0,0 +1,47 @@
+package stepup
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	stepupDTO "crypto_analyzer-api_gateway/internal/controller/stepup/dto"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (con StepUpController) EnrollTOTP(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	res, err := con.stepUpUsecaseObj.EnrollTOTP(ctx)
+	if err != nil {
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unknown, "failed to enroll totp")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to enroll totp")
+		}
+
+		log.Error("failed to enroll totp", zap.String("user_id", user.Id), zap.Error(err))
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	log.Info("totp enrollment started", zap.String("user_id", user.Id))
+
+	return c.Status(fiber.StatusOK).JSON(stepupDTO.TOTPEnrollment{
+		Secret:     res.Secret,
+		OTPAuthURL: res.OTPAuthURL,
+	})
+}
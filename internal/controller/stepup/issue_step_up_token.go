@@ -0,0 +1,76 @@
+package stepup
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/cookie"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/mapper"
+	stepupDTO "crypto_analyzer-api_gateway/internal/controller/stepup/dto"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
+	"strconv"
+)
+
+func (con StepUpController) IssueStepUpToken(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := logger.FromContext(ctx)
+
+	userVal := c.Locals("user")
+	user, ok := userVal.(*portfolio.User)
+	if !ok || user == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+			Status:  fiber.StatusUnauthorized,
+			Error:   "unauthorized",
+			Message: "user not found in context",
+		})
+	}
+
+	code, httpErr := parseCode(c)
+	if httpErr != nil {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	accessToken, _ := c.Locals(cookie.AccessTokenLocal).(string)
+
+	token, err := con.stepUpUsecaseObj.IssueToken(ctx, user.Id, accessToken, c.IP(), code)
+	if err != nil {
+		var locked *auth.LockedError
+		switch {
+		case errors.Is(err, auth.ErrInvalidStepUp):
+			log.Warn("invalid totp code", zap.String("user_id", user.Id))
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.HTTPError{
+				Status:  fiber.StatusUnauthorized,
+				Error:   "unauthenticated",
+				Message: "invalid code",
+			})
+		case errors.As(err, &locked):
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(dto.HTTPError{
+				Status:  fiber.StatusTooManyRequests,
+				Error:   "too_many_attempts",
+				Message: "too many invalid codes, try again later",
+			})
+		}
+
+		log.Error("failed to issue step-up token", zap.String("user_id", user.Id), zap.Error(err))
+
+		// Ошибка не от Auth Service — недоступно хранилище попыток или токенов
+		httpErr := mapper.GrpcCodeToHTTPError(codes.Unavailable, "failed to issue step-up token")
+		if st, ok := status.FromError(err); ok {
+			httpErr = mapper.GrpcCodeToHTTPError(st.Code(), "failed to issue step-up token")
+		}
+
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(stepupDTO.StepUpToken{
+		StepUpToken: token,
+		ExpiresIn:   int(con.stepUpUsecaseObj.TTL().Seconds()),
+	})
+}
@@ -0,0 +1,36 @@
+package stepup
+
+import (
+	"crypto_analyzer-api_gateway/internal/controller/portfolio/dto"
+	stepupDTO "crypto_analyzer-api_gateway/internal/controller/stepup/dto"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"crypto_analyzer-api_gateway/internal/usecase/auth"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"regexp"
+)
+
+type StepUpController struct {
+	stepUpUsecaseObj *auth.StepUpUsecase
+}
+
+func NewStepUpController(stepUpUsecaseObj *auth.StepUpUsecase) *StepUpController {
+	return &StepUpController{stepUpUsecaseObj: stepUpUsecaseObj}
+}
+
+var codePattern = regexp.MustCompile(`^[0-9]{6,8}$`)
+
+// parseCode читает {"code": "123456"}; при ошибке возвращает готовый ответ 400
+func parseCode(c *fiber.Ctx) (string, *dto.HTTPError) {
+	var codeObj stepupDTO.CodeObject
+	if err := c.BodyParser(&codeObj); err != nil {
+		logger.FromContext(c.UserContext()).Warn("failed to parse totp code", zap.Error(err))
+		return "", &dto.HTTPError{Status: fiber.StatusBadRequest, Error: "bad_request", Message: "wrong code data"}
+	}
+
+	if !codePattern.MatchString(codeObj.Code) {
+		return "", &dto.HTTPError{Status: fiber.StatusBadRequest, Error: "bad_request", Message: "code must be 6-8 digits"}
+	}
+
+	return codeObj.Code, nil
+}
@@ -0,0 +1,42 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	AccessRead      = "read"
+	AccessReadWrite = "read_write"
+)
+
+var (
+	ErrInvalidAccess   = errors.New("access must be read or read_write")
+	ErrNameRequired    = errors.New("name is required")
+	ErrTooManyKeys     = errors.New("too many api keys")
+	ErrScopeNotGranted = errors.New("cannot grant access the user does not have")
+	// Ключ без портфелей не выпускается: пустой список означал бы доступ ко всем портфелям
+	ErrPortfolioIdsRequired = errors.New("portfolio_ids must list at least one portfolio")
+	ErrInvalidPortfolioId   = errors.New("portfolio_ids must be positive")
+)
+
+type APIKey struct {
+	Id           string
+	UserId       string
+	Username     string
+	Email        string
+	Name         string
+	PortfolioIds []int32
+	Access       string
+	CreatedAt    time.Time
+}
+
+// APIKeyStoreContract хранит ключи по хешу секрета; открытый секрет в хранилище не попадает.
+// GetBySecret возвращает nil, nil, если ключ не найден.
+type APIKeyStoreContract interface {
+	Create(ctx context.Context, key APIKey, secret string) error
+	GetBySecret(ctx context.Context, secret string) (*APIKey, error)
+	ListByUser(ctx context.Context, userId string) ([]APIKey, error)
+	Delete(ctx context.Context, userId, id string) (bool, error)
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	ActionImpersonate = "impersonate"
+	// ActionImpersonateOverride — разрешённый через явный override деструктивный запрос под чужой личностью
+	ActionImpersonateOverride = "impersonate_override"
+)
+
+// Record — запись журнала аудита. Записи только добавляются, изменение и удаление не предусмотрены.
+type Record struct {
+	Time          time.Time
+	Action        string
+	ActorId       string
+	ActorUsername string
+	TargetId      string
+	Method        string
+	Path          string
+	RequestId     string
+	IP            string
+	Reason        string
+}
+
+type AuditLogContract interface {
+	Append(ctx context.Context, record Record) error
+}
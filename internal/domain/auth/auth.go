@@ -1,4 +1,158 @@
 package auth
 
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// ClientInfo — данные клиента, от которого пришёл запрос на вход.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// Session — устройство/клиент, получивший токены через gateway. Токены не отдаются клиенту.
+// Открытый AccessToken передаётся только в Save: хранилище оставляет от него jti, хеш и срок действия,
+// которых достаточно для отзыва. RefreshToken хранится зашифрованным и нужен только для Logout.
+// LastUsedAt — время последнего обновления токенов (вход или refresh), а не последнего запроса.
+type Session struct {
+	Id                   string
+	UserId               string
+	UserAgent            string
+	IP                   string
+	CreatedAt            time.Time
+	LastUsedAt           time.Time
+	AccessToken          string
+	AccessTokenID        string
+	AccessTokenHash      string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+}
+
 type AuthServiceContract interface {
+	Register(ctx context.Context, username, email, password string) (Tokens, error)
+	Login(ctx context.Context, email, username, password string) (Tokens, error)
+	Refresh(ctx context.Context, refreshToken string) (Tokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	Verify(ctx context.Context, accessToken string) (portfolio.User, error)
+	ExchangeExternalIdentity(ctx context.Context, identity ExternalIdentity) (Tokens, error)
+	GetUser(ctx context.Context, userId string) (portfolio.User, error)
+	LookupUserByUsername(ctx context.Context, username string) (portfolio.PublicProfile, error)
+	// ResolveAccount возвращает id аккаунта по email или username; не найден — codes.NotFound
+	ResolveAccount(ctx context.Context, email, username string) (string, error)
+	// TOTP-методы работают от имени пользователя из ctx (подписанная личность)
+	EnrollTOTP(ctx context.Context) (TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, code string) error
+	VerifyTOTP(ctx context.Context, code string) (bool, error)
+	TOTPEnabled(ctx context.Context) (bool, error)
+}
+
+// SessionStoreContract хранит активные сессии пользователя.
+// Get* возвращают nil, nil, если сессия не найдена.
+type SessionStoreContract interface {
+	Save(ctx context.Context, session Session) error
+	Get(ctx context.Context, userId, id string) (*Session, error)
+	GetByRefreshToken(ctx context.Context, refreshToken string) (*Session, error)
+	ListByUser(ctx context.Context, userId string) ([]Session, error)
+	Delete(ctx context.Context, session Session) error
+}
+
+// VerifyCacheContract — кеш результатов Verify. Get возвращает nil, nil при промахе.
+type VerifyCacheContract interface {
+	Get(ctx context.Context, token string) (*portfolio.User, error)
+	Set(ctx context.Context, token string, user *portfolio.User, ttl time.Duration) error
+	Delete(ctx context.Context, token string) error
+}
+
+// RevocationListContract — denylist отозванных access-токенов.
+// Токен идентифицируется по jti, а при его отсутствии — по хешу.
+type RevocationListContract interface {
+	RevokeToken(ctx context.Context, token string) error
+	RevokeTokenID(ctx context.Context, tokenID string, expiresAt time.Time) error
+	RevokeTokenHash(ctx context.Context, tokenHash string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, token string) (bool, error)
+}
+
+// LockedError — вход временно заблокирован после серии неудачных попыток.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("login is locked, retry after %s", e.RetryAfter)
+}
+
+// LoginGuardContract считает неудачные входы по аккаунту и IP.
+// Check и RegisterFailure возвращают оставшееся время блокировки, 0 — вход разрешён.
+type LoginGuardContract interface {
+	Check(ctx context.Context, account, ip string) (time.Duration, error)
+	RegisterFailure(ctx context.Context, account, ip string) (time.Duration, error)
+	Reset(ctx context.Context, account string) error
+}
+
+var (
+	ErrInvalidOIDCState = errors.New("invalid or expired oidc state")
+	ErrInvalidIDToken   = errors.New("invalid id token")
+	ErrOIDCCodeRejected = errors.New("authorization code rejected by oidc provider")
+)
+
+// ExternalIdentity — пользователь, подтверждённый внешним OIDC-провайдером.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Username      string
+	Name          string
+}
+
+// OIDCState — данные незавершённого входа через OIDC, привязанные к параметру state.
+type OIDCState struct {
+	Nonce         string
+	CodeVerifier  string
+	RedirectAfter string
+	// BrowserBindingHash — SHA-256 значения из куки браузера, начавшего вход
+	BrowserBindingHash string
+}
+
+// OIDCStateStoreContract хранит state между редиректом к провайдеру и callback.
+// Take одноразовый: возвращает nil, nil, если state не найден или уже использован.
+type OIDCStateStoreContract interface {
+	Save(ctx context.Context, state string, data OIDCState, ttl time.Duration) error
+	Take(ctx context.Context, state string) (*OIDCState, error)
+}
+
+// OIDCProviderContract — authorization code flow с PKCE (S256) у внешнего провайдера.
+type OIDCProviderContract interface {
+	Name() string
+	AuthCodeURL(state, nonce, codeChallenge string) string
+	// Exchange обменивает code на ID token, проверяет его подпись, iss, aud, exp и nonce
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (ExternalIdentity, error)
+}
+
+var (
+	ErrStepUpRequired = errors.New("step-up authentication required")
+	ErrInvalidStepUp  = errors.New("invalid step-up proof")
+)
+
+// TOTPEnrollment — секрет для приложения-аутентификатора. Хранится в Auth Service.
+type TOTPEnrollment struct {
+	Secret     string
+	OTPAuthURL string
+}
+
+// StepUpStoreContract хранит короткоживущие step-up токены, выданные после проверки TOTP.
+type StepUpStoreContract interface {
+	// Issue выдаёт токен пользователю в рамках сессии, которой принадлежит accessToken
+	Issue(ctx context.Context, userId, accessToken string, ttl time.Duration) (string, error)
+	// Match — токен не истёк и выдан этому пользователю с этим access-токеном
+	Match(ctx context.Context, token, userId, accessToken string) (bool, error)
 }
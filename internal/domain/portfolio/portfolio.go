@@ -2,12 +2,49 @@ package portfolio
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
+const (
+	RoleAdmin = "admin"
+
+	ScopePortfolioRead  = "portfolio:read"
+	ScopePortfolioWrite = "portfolio:write"
+)
+
+// DefaultScopes выдаются пользователю, если Auth Service не вернул scopes явно.
+var DefaultScopes = []string{ScopePortfolioRead, ScopePortfolioWrite}
+
 type User struct {
 	Id       string
 	Username string
 	Email    string
+	Roles    []string
+	Scopes   []string
+	// AllowedPortfolioIds ограничивает доступ конкретными портфелями (API-ключи). Пусто — без ограничений.
+	AllowedPortfolioIds []int32
+}
+
+func (u *User) HasRole(role string) bool {
+	return slices.Contains(u.Roles, role)
+}
+
+func (u *User) IsPortfolioRestricted() bool {
+	return len(u.AllowedPortfolioIds) > 0
+}
+
+func (u *User) CanAccessPortfolio(portfolioId int32) bool {
+	return !u.IsPortfolioRestricted() || slices.Contains(u.AllowedPortfolioIds, portfolioId)
+}
+
+// HasScope — администратору доступны все scopes.
+func (u *User) HasScope(scope string) bool {
+	return u.HasRole(RoleAdmin) || slices.Contains(u.Scopes, scope)
 }
 
 type Portfolio struct {
@@ -16,31 +53,338 @@ type Portfolio struct {
 	IsPublic bool
 }
 
+// PortfolioUpdate — частичное изменение портфеля; nil-поля не меняются.
+type PortfolioUpdate struct {
+	Name     *string
+	IsPublic *bool
+}
+
 type PortfolioContent struct {
 	Assets map[string]float64
 }
 
 type PricePoint struct {
-	Timestamp string
+	Timestamp time.Time
 	Value     float64
 }
 
+// HistoryQuery — запрос сырых точек истории в полуинтервале [From, To).
+// Cursor — NextCursor предыдущей страницы Portfolio Service.
+type HistoryQuery struct {
+	PortfolioId int
+	From        time.Time
+	To          time.Time
+	Symbols     []string
+	Cursor      string
+	PageSize    int
+}
+
 type PortfolioHistory struct {
-	History map[string][]PricePoint
+	History    map[string][]PricePoint
+	NextCursor string
+}
+
+const (
+	HistoryInterval1h = "1h"
+	HistoryInterval1d = "1d"
+	HistoryInterval1w = "1w"
+
+	HistoryAggregationLast = "last"
+	HistoryAggregationOHLC = "ohlc"
+)
+
+// HistoryRequest — запрос истории с прореживанием до интервала.
+// Страница содержит не более Limit интервалов; Cursor — NextCursor предыдущей страницы.
+type HistoryRequest struct {
+	PortfolioId int
+	From        time.Time
+	To          time.Time
+	Interval    string
+	Aggregation string
+	Symbols     []string
+	Cursor      string
+	Limit       int
+}
+
+// HistoryBucket — значения за интервал, начинающийся в Start.
+// Для агрегации last заполнен только Close.
+type HistoryBucket struct {
+	Start time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+type AggregatedHistory struct {
+	Interval    string
+	Aggregation string
+	Buckets     map[string][]HistoryBucket
+	NextCursor  string
+}
+
+const (
+	AssetOpUpsert = "upsert"
+	AssetOpDelete = "delete"
+
+	AssetOpApplied        = "applied"
+	AssetOpFailed         = "failed"
+	AssetOpSkipped        = "skipped"
+	AssetOpRolledBack     = "rolled_back"
+	AssetOpRollbackFailed = "rollback_failed"
+)
+
+type AssetOperation struct {
+	Op     string
+	Symbol string
+	Amount float64
+}
+
+// AssetOperationResult — итог одной операции пакета; Err заполнен для failed и rollback_failed.
+type AssetOperationResult struct {
+	Operation AssetOperation
+	Status    string
+	Err       error
+}
+
+// AssetBatchResult — результаты в порядке операций запроса.
+// RolledBack — в режиме all_or_nothing все применённые операции успешно отменены.
+type AssetBatchResult struct {
+	Results    []AssetOperationResult
+	Failed     bool
+	RolledBack bool
+}
+
+var (
+	ErrDuplicateBatchSymbol = errors.New("duplicate symbol in batch")
+	ErrAssetBatchTooLarge   = errors.New("too many operations in batch")
+)
+
+// Holding — позиция портфеля; Profit заполнен только при выгрузке с колонками прибыли.
+type Holding struct {
+	Symbol string
+	Amount float64
+	Profit *AssetProfit
+}
+
+const (
+	HoldingAdd    = "add"
+	HoldingUpdate = "update"
+	HoldingRemove = "remove"
+)
+
+type HoldingChange struct {
+	Symbol string
+	Action string
+	Before float64
+	After  float64
+}
+
+// ImportResult — разница между файлом и портфелем. Batch равен nil при dry run
+// и когда менять нечего.
+type ImportResult struct {
+	Changes   []HoldingChange
+	Unchanged int
+	Batch     *AssetBatchResult
+}
+
+const (
+	SummaryOK      = "ok"
+	SummaryPartial = "partial"
+	SummaryFailed  = "failed"
+)
+
+// PortfolioSummaryStatus — итог загрузки одного портфеля для сводки.
+// ContentErr и ProfitErr заполнены для неудавшихся вызовов.
+type PortfolioSummaryStatus struct {
+	Portfolio    Portfolio
+	Status       string
+	CurrentValue float64
+	Profit       float64
+	ContentErr   error
+	ProfitErr    error
+}
+
+// SummaryHolding — позиция, сложенная по всем портфелям. CurrentValue, Invested и Profit
+// учитывают только портфели, для которых удалось получить прибыль.
+type SummaryHolding struct {
+	Symbol       string
+	Amount       float64
+	Invested     float64
+	CurrentValue float64
+	Profit       float64
+	PortfolioIds []int32
+}
+
+// PortfoliosSummary — сводка по всем портфелям; Partial — хотя бы один портфель загружен не полностью.
+type PortfoliosSummary struct {
+	Portfolios    []PortfolioSummaryStatus
+	Holdings      []SummaryHolding
+	TotalInvested float64
+	TotalValue    float64
+	TotalProfit   float64
+	Partial       bool
+}
+
+const (
+	TransactionBuy         = "buy"
+	TransactionSell        = "sell"
+	TransactionTransferIn  = "transfer_in"
+	TransactionTransferOut = "transfer_out"
+)
+
+// Transaction — сделка по активу. Price — цена за единицу, Fee — комиссия.
+type Transaction struct {
+	Id          int64
+	PortfolioId int
+	Type        string
+	Symbol      string
+	Amount      float64
+	Price       float64
+	Fee         float64
+	ExecutedAt  time.Time
+	Note        string
+}
+
+// Reduces — сделка уменьшает количество актива
+func (t Transaction) Reduces() bool {
+	return t.Type == TransactionSell || t.Type == TransactionTransferOut
+}
+
+type TransactionQuery struct {
+	PortfolioId int
+	Symbol      string
+	PageSize    int
+	Cursor      string
+}
+
+type TransactionPage struct {
+	Transactions []Transaction
+	NextCursor   string
+}
+
+// InsufficientHoldingError — продажа или вывод больше, чем есть в портфеле.
+type InsufficientHoldingError struct {
+	Symbol    string
+	Available float64
+	Requested float64
+}
+
+func (e *InsufficientHoldingError) Error() string {
+	return fmt.Sprintf("insufficient %s: available %s, requested %s", e.Symbol,
+		strconv.FormatFloat(e.Available, 'f', -1, 64), strconv.FormatFloat(e.Requested, 'f', -1, 64))
+}
+
+// Symbol — актив из реестра. Precision — допустимое число знаков после запятой в количестве.
+type Symbol struct {
+	Ticker    string
+	Name      string
+	Aliases   []string
+	Precision int
+}
+
+type SymbolRegistryContract interface {
+	// Resolve ищет тикер или псевдоним без учёта регистра
+	Resolve(symbol string) (Symbol, bool)
+	// Search ищет по префиксу тикера, псевдонима или названия; точное совпадение идёт первым
+	Search(prefix string, limit int) []Symbol
+}
+
+// AssetViolation — нарушение в операции над активом; Index — номер операции в запросе.
+type AssetViolation struct {
+	Index   int
+	Field   string
+	Message string
+}
+
+// AssetValidationError — операции не применялись: символ неизвестен или количество точнее, чем допускает актив.
+type AssetValidationError struct {
+	Violations []AssetViolation
+}
+
+func (e *AssetValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+
+	return "invalid assets: " + strings.Join(messages, "; ")
 }
 
+var (
+	ErrInvalidHistoryRange = errors.New("invalid history range")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrHistoryTooLarge     = errors.New("history window is too large")
+	ErrExportTooLarge      = errors.New("portfolio is too large to export")
+)
+
 type PublicPortfolio struct {
 	PortfolioId int32
 	Name        string
 	Assets      map[string]float64
 }
 
+// PublicProfile — публичные данные пользователя из Auth Service.
+type PublicProfile struct {
+	UserId      string
+	Username    string
+	DisplayName string
+	AvatarURL   string
+}
+
+// PublicPortfolios — публичные портфели вместе с профилем владельца.
+type PublicPortfolios struct {
+	Owner      PublicProfile
+	Portfolios []PublicPortfolio
+}
+
+var ErrUserNotFound = errors.New("user not found")
+
+// ProfileLookupContract ищет пользователя по username без учёта регистра; не найден — codes.NotFound.
+type ProfileLookupContract interface {
+	LookupUserByUsername(ctx context.Context, username string) (PublicProfile, error)
+}
+
+// ProfileCacheContract — кеш профилей по username. Get возвращает nil, nil при промахе
+// и ErrUserNotFound, если закешировано отсутствие пользователя.
+type ProfileCacheContract interface {
+	Get(ctx context.Context, username string) (*PublicProfile, error)
+	Set(ctx context.Context, username string, profile PublicProfile) error
+	SetNotFound(ctx context.Context, username string) error
+}
+
+type AssetProfit struct {
+	Symbol       string
+	Amount       float64
+	Invested     float64
+	CurrentPrice float64
+	CurrentValue float64
+	Profit       float64
+}
+
+// PortfolioProfit — прибыль по активам и итоги портфеля.
+// ROIPercent равен nil, если в портфель ничего не вложено; Best и Worst — nil для пустого портфеля.
+type PortfolioProfit struct {
+	Assets        []AssetProfit
+	TotalInvested float64
+	CurrentValue  float64
+	Profit        float64
+	ROIPercent    *float64
+	Best          *AssetProfit
+	Worst         *AssetProfit
+}
+
 type PortfolioServiceContract interface {
 	CreateNewPortfolio(ctx context.Context, name string, isPublic bool) (Portfolio, error)
+	UpdatePortfolio(ctx context.Context, portfolioId int, update PortfolioUpdate) (Portfolio, error)
+	DeletePortfolio(ctx context.Context, portfolioId int) error
 	GetPortfolioContentById(ctx context.Context, portfolioID int) (PortfolioContent, error)
 	UpsertAsset(ctx context.Context, portfolioId int, symbol string, amount float64) error
 	DeleteAsset(ctx context.Context, portfolioId int, symbol string) error
 	GetAllPortfolios(ctx context.Context) ([]Portfolio, error)
-	GetPortfolioHistory(ctx context.Context, id, page, pageSize int32) (PortfolioHistory, error)
+	GetPortfolioHistory(ctx context.Context, query HistoryQuery) (PortfolioHistory, error)
 	GetPublicPortfolios(ctx context.Context, userId int) ([]PublicPortfolio, error)
+	GetPortfolioProfit(ctx context.Context, portfolioId int) ([]AssetProfit, error)
+	AddTransaction(ctx context.Context, tx Transaction) (Transaction, error)
+	ListTransactions(ctx context.Context, query TransactionQuery) (TransactionPage, error)
 }
@@ -0,0 +1,161 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto_analyzer-api_gateway/internal/domain/apikey"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	secretKeyPrefix = "apikey:secret:"
+	userKeyPrefix   = "apikey:user:"
+)
+
+var _ apikey.APIKeyStoreContract = (*APIKeyStore)(nil)
+
+type record struct {
+	Id           string    `json:"id"`
+	UserId       string    `json:"user_id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	Name         string    `json:"name"`
+	PortfolioIds []int32   `json:"portfolio_ids"`
+	Access       string    `json:"access"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func toRecord(k apikey.APIKey) record {
+	return record{
+		Id:           k.Id,
+		UserId:       k.UserId,
+		Username:     k.Username,
+		Email:        k.Email,
+		Name:         k.Name,
+		PortfolioIds: k.PortfolioIds,
+		Access:       k.Access,
+		CreatedAt:    k.CreatedAt,
+	}
+}
+
+func (r record) toDomain() apikey.APIKey {
+	return apikey.APIKey{
+		Id:           r.Id,
+		UserId:       r.UserId,
+		Username:     r.Username,
+		Email:        r.Email,
+		Name:         r.Name,
+		PortfolioIds: r.PortfolioIds,
+		Access:       r.Access,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+type APIKeyStore struct {
+	client *redis.Client
+}
+
+func NewAPIKeyStore(client *redis.Client) *APIKeyStore {
+	return &APIKeyStore{client: client}
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *APIKeyStore) Create(ctx context.Context, key apikey.APIKey, secret string) error {
+	data, err := json.Marshal(toRecord(key))
+	if err != nil {
+		return fmt.Errorf("failed to encode api key: %w", err)
+	}
+
+	hash := hashSecret(secret)
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, secretKeyPrefix+hash, data, 0)
+	pipe.HSet(ctx, userKeyPrefix+key.UserId, key.Id, hash)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store api key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *APIKeyStore) GetBySecret(ctx context.Context, secret string) (*apikey.APIKey, error) {
+	data, err := s.client.Get(ctx, secretKeyPrefix+hashSecret(secret)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode api key: %w", err)
+	}
+
+	key := r.toDomain()
+	return &key, nil
+}
+
+func (s *APIKeyStore) ListByUser(ctx context.Context, userId string) ([]apikey.APIKey, error) {
+	hashes, err := s.client.HVals(ctx, userKeyPrefix+userId).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	if len(hashes) == 0 {
+		return []apikey.APIKey{}, nil
+	}
+
+	keys := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		keys = append(keys, secretKeyPrefix+h)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	result := make([]apikey.APIKey, 0, len(values))
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		var r record
+		if err := json.Unmarshal([]byte(str), &r); err != nil {
+			return nil, fmt.Errorf("failed to decode api key: %w", err)
+		}
+		result = append(result, r.toDomain())
+	}
+
+	return result, nil
+}
+
+func (s *APIKeyStore) Delete(ctx context.Context, userId, id string) (bool, error) {
+	hash, err := s.client.HGet(ctx, userKeyPrefix+userId, id).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, secretKeyPrefix+hash)
+	pipe.HDel(ctx, userKeyPrefix+userId, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	return true, nil
+}
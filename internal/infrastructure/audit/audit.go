@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/audit"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const streamKey = "audit:log"
+
+var _ audit.AuditLogContract = (*AuditLog)(nil)
+
+// AuditLog пишет записи в Redis Stream: XADD только добавляет, а id записи монотонно растёт.
+// Stream не обрезается — хранение и выгрузку обеспечивает эксплуатация.
+type AuditLog struct {
+	client *redis.Client
+}
+
+func NewAuditLog(client *redis.Client) *AuditLog {
+	return &AuditLog{client: client}
+}
+
+func (l *AuditLog) Append(ctx context.Context, record audit.Record) error {
+	err := l.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]any{
+			"time":           record.Time.UTC().Format(time.RFC3339Nano),
+			"action":         record.Action,
+			"actor_id":       record.ActorId,
+			"actor_username": record.ActorUsername,
+			"target_id":      record.TargetId,
+			"method":         record.Method,
+			"path":           record.Path,
+			"request_id":     record.RequestId,
+			"ip":             record.IP,
+			"reason":         record.Reason,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to append audit record: %w", err)
+	}
+
+	return nil
+}
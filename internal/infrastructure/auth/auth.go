@@ -1,8 +1,15 @@
 package auth
 
 import (
+	"context"
 	authpb "crypto_analyzer-api_gateway/gen/go/auth"
 	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type AuthServiceClient struct {
@@ -12,3 +19,280 @@ type AuthServiceClient struct {
 func NewAuthServiceClient(grpcClient authpb.AuthServiceClient) domain.AuthServiceContract {
 	return AuthServiceClient{grpcClient: grpcClient}
 }
+
+func (c AuthServiceClient) Register(ctx context.Context, username, email, password string) (domain.Tokens, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.Register(ctx, &authpb.RegisterRequest{
+		Username: username,
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to register user via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return domain.Tokens{}, err
+	}
+
+	return domain.Tokens{
+		AccessToken:  res.Token,
+		RefreshToken: res.RefreshToken,
+	}, nil
+}
+
+func (c AuthServiceClient) Login(ctx context.Context, email, username, password string) (domain.Tokens, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.Login(ctx, &authpb.LoginRequest{
+		Email:    email,
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to login via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return domain.Tokens{}, err
+	}
+
+	return domain.Tokens{
+		AccessToken:  res.Token,
+		RefreshToken: res.RefreshToken,
+	}, nil
+}
+
+func (c AuthServiceClient) Refresh(ctx context.Context, refreshToken string) (domain.Tokens, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.Refresh(ctx, &authpb.RefreshRequest{RefreshToken: refreshToken})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to refresh token via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return domain.Tokens{}, err
+	}
+
+	return domain.Tokens{
+		AccessToken:  res.Token,
+		RefreshToken: res.RefreshToken,
+	}, nil
+}
+
+func (c AuthServiceClient) Logout(ctx context.Context, refreshToken string) error {
+	log := logger.FromContext(ctx)
+
+	_, err := c.grpcClient.Logout(ctx, &authpb.LogoutRequest{RefreshToken: refreshToken})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to logout via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (c AuthServiceClient) Verify(ctx context.Context, accessToken string) (portfolio.User, error) {
+	log := logger.FromContext(ctx)
+
+	mdCTX := metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", accessToken))
+
+	res, err := c.grpcClient.Verify(mdCTX, &authpb.VerifyRequest{})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to verify token via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return portfolio.User{}, err
+	}
+
+	return portfolio.User{
+		Id:       res.UserId,
+		Username: res.Username,
+		Email:    res.Email,
+		Roles:    res.Roles,
+		Scopes:   res.Scopes,
+	}, nil
+}
+
+func (c AuthServiceClient) ExchangeExternalIdentity(ctx context.Context,
+	identity domain.ExternalIdentity) (domain.Tokens, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.ExchangeExternalIdentity(ctx, &authpb.ExchangeExternalIdentityRequest{
+		Provider:      identity.Provider,
+		Subject:       identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		Username:      identity.Username,
+		Name:          identity.Name,
+	})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to exchange external identity via gRPC",
+			zap.String("provider", identity.Provider),
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return domain.Tokens{}, err
+	}
+
+	return domain.Tokens{
+		AccessToken:  res.Token,
+		RefreshToken: res.RefreshToken,
+	}, nil
+}
+
+func (c AuthServiceClient) GetUser(ctx context.Context, userId string) (portfolio.User, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.GetUser(ctx, &authpb.GetUserRequest{UserId: userId})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to get user via gRPC",
+			zap.String("user_id", userId),
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return portfolio.User{}, err
+	}
+
+	return portfolio.User{
+		Id:       res.UserId,
+		Username: res.Username,
+		Email:    res.Email,
+		Roles:    res.Roles,
+		Scopes:   res.Scopes,
+	}, nil
+}
+
+func (c AuthServiceClient) LookupUserByUsername(ctx context.Context, username string) (portfolio.PublicProfile, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.LookupUserByUsername(ctx, &authpb.LookupUserByUsernameRequest{Username: username})
+	if err != nil {
+		// Несуществующий username — обычный ответ для публичных ссылок, не ошибка
+		if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+			log.Warn("failed to lookup user via gRPC",
+				zap.String("username", username),
+				zap.String("grpc_code", st.Code().String()),
+				zap.Error(err),
+			)
+		}
+
+		return portfolio.PublicProfile{}, err
+	}
+
+	return portfolio.PublicProfile{
+		UserId:      res.UserId,
+		Username:    res.Username,
+		DisplayName: res.DisplayName,
+		AvatarURL:   res.AvatarUrl,
+	}, nil
+}
+
+func (c AuthServiceClient) ResolveAccount(ctx context.Context, email, username string) (string, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.ResolveAccount(ctx, &authpb.ResolveAccountRequest{Email: email, Username: username})
+	if err != nil {
+		if st, _ := status.FromError(err); st.Code() != codes.NotFound {
+			log.Warn("failed to resolve account via gRPC",
+				zap.String("grpc_code", st.Code().String()),
+				zap.Error(err),
+			)
+		}
+
+		return "", err
+	}
+
+	return res.UserId, nil
+}
+
+func (c AuthServiceClient) EnrollTOTP(ctx context.Context) (domain.TOTPEnrollment, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.EnrollTOTP(ctx, &authpb.EnrollTOTPRequest{})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to enroll totp via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return domain.TOTPEnrollment{}, err
+	}
+
+	return domain.TOTPEnrollment{
+		Secret:     res.Secret,
+		OTPAuthURL: res.OtpauthUrl,
+	}, nil
+}
+
+func (c AuthServiceClient) ConfirmTOTP(ctx context.Context, code string) error {
+	log := logger.FromContext(ctx)
+
+	_, err := c.grpcClient.ConfirmTOTP(ctx, &authpb.ConfirmTOTPRequest{Code: code})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to confirm totp via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (c AuthServiceClient) VerifyTOTP(ctx context.Context, code string) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.VerifyTOTP(ctx, &authpb.VerifyTOTPRequest{Code: code})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to verify totp via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return false, err
+	}
+
+	return res.Valid, nil
+}
+
+func (c AuthServiceClient) TOTPEnabled(ctx context.Context) (bool, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.grpcClient.GetTOTPStatus(ctx, &authpb.GetTOTPStatusRequest{})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Warn("failed to get totp status via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return false, err
+	}
+
+	return res.Enabled, nil
+}
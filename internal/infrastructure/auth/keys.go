@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	authpb "crypto_analyzer-api_gateway/gen/go/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+)
+
+var _ jwt.KeySource = (*PublicKeySource)(nil)
+
+// PublicKeySource получает набор публичных ключей подписи (JWKS) из Auth Service.
+type PublicKeySource struct {
+	grpcClient authpb.AuthServiceClient
+}
+
+func NewPublicKeySource(grpcClient authpb.AuthServiceClient) *PublicKeySource {
+	return &PublicKeySource{grpcClient: grpcClient}
+}
+
+func (s *PublicKeySource) FetchKeys(ctx context.Context) ([]jwt.JWK, error) {
+	res, err := s.grpcClient.GetPublicKeys(ctx, &authpb.GetPublicKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]jwt.JWK, 0, len(res.Keys))
+	for _, k := range res.Keys {
+		keys = append(keys, jwt.JWK{
+			Kid: k.Kid,
+			Kty: k.Kty,
+			Alg: k.Alg,
+			Use: k.Use,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+			Y:   k.Y,
+		})
+	}
+
+	return keys, nil
+}
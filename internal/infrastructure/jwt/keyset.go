@@ -0,0 +1,184 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var (
+	ErrKeysUnavailable = errors.New("signing keys unavailable")
+	ErrUnknownKey      = errors.New("unknown signing key")
+)
+
+// minRefreshInterval ограничивает внеплановые обновления при неизвестном kid,
+// чтобы поток токенов с мусорным kid не превратился в DoS источника ключей.
+const minRefreshInterval = 30 * time.Second
+
+type KeySource interface {
+	FetchKeys(ctx context.Context) ([]JWK, error)
+}
+
+type publicKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+type KeySet struct {
+	source          KeySource
+	refreshInterval time.Duration
+
+	mu          sync.RWMutex
+	keys        map[string]publicKey
+	lastAttempt time.Time
+	lastErr     error
+	refreshMu   sync.Mutex
+}
+
+func NewKeySet(source KeySource, refreshInterval time.Duration) *KeySet {
+	return &KeySet{
+		source:          source,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// Start загружает ключи и периодически обновляет их до отмены ctx.
+// Ошибка первичной загрузки не фатальна: ключи будут запрошены повторно.
+func (s *KeySet) Start(ctx context.Context) {
+	log := logger.FromContext(ctx)
+
+	if err := s.Refresh(ctx); err != nil {
+		log.Warn("failed to load signing keys", zap.Error(err))
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.Refresh(ctx); err != nil {
+					log.Warn("failed to refresh signing keys", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+func (s *KeySet) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	return s.refreshLocked(ctx)
+}
+
+// refreshOnDemand обновляет набор, только если с последней попытки прошло minRefreshInterval.
+// Интервал проверяется повторно под refreshMu: запросы с неизвестным kid, дождавшиеся
+// чужого обновления, не делают собственных.
+func (s *KeySet) refreshOnDemand(ctx context.Context) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	s.mu.RLock()
+	stale := time.Since(s.lastAttempt) >= minRefreshInterval
+	s.mu.RUnlock()
+
+	if stale {
+		_ = s.refreshLocked(ctx)
+	}
+}
+
+// refreshLocked вызывается под refreshMu
+func (s *KeySet) refreshLocked(ctx context.Context) error {
+	s.mu.Lock()
+	s.lastAttempt = time.Now()
+	s.mu.Unlock()
+
+	err := s.refresh(ctx)
+
+	s.mu.Lock()
+	s.lastErr = err
+	s.mu.Unlock()
+
+	return err
+}
+
+func (s *KeySet) refresh(ctx context.Context) error {
+	jwks, err := s.source.FetchKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+
+	log := logger.FromContext(ctx)
+	keys := make(map[string]publicKey, len(jwks))
+	for _, k := range jwks {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		pub, err := k.PublicKey()
+		if err != nil {
+			log.Warn("skipping invalid signing key", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+
+		keys[k.Kid] = publicKey{key: pub, alg: k.Alg}
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("key source returned no usable keys")
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *KeySet) lookup(kid string) (publicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok := s.keys[kid]
+	return k, ok
+}
+
+// Key возвращает ключ по kid. При неизвестном kid (ротация) набор обновляется вне расписания.
+func (s *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, string, error) {
+	if k, ok := s.lookup(kid); ok {
+		return k.key, k.alg, nil
+	}
+
+	s.mu.RLock()
+	canRefresh := time.Since(s.lastAttempt) >= minRefreshInterval
+	s.mu.RUnlock()
+
+	if canRefresh {
+		s.refreshOnDemand(ctx)
+	}
+
+	if k, ok := s.lookup(kid); ok {
+		return k.key, k.alg, nil
+	}
+
+	// Если источник ключей сейчас недоступен, неизвестный kid может оказаться
+	// свежим ключом после ротации — это не повод считать токен поддельным.
+	s.mu.RLock()
+	lastErr := s.lastErr
+	s.mu.RUnlock()
+
+	if lastErr != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrKeysUnavailable, lastErr)
+	}
+
+	return nil, "", fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+}
@@ -0,0 +1,13 @@
+package jwt
+
+import (
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,192 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrTokenNotYetValid = errors.New("token not valid yet")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+)
+
+// leeway компенсирует расхождение часов между Auth Service и gateway.
+const leeway = 30 * time.Second
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type Verifier struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+}
+
+func NewVerifier(keys *KeySet, issuer, audience string) *Verifier {
+	return &Verifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+	}
+}
+
+func (v *Verifier) Verify(ctx context.Context, token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+
+	var h header
+	if err := json.Unmarshal(headerBytes, &h); err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+
+	key, keyAlg, err := v.keys.Key(ctx, h.Kid)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	// Алгоритм берём из ключа, а не доверяем заголовку токена
+	if keyAlg != "" && keyAlg != h.Alg {
+		return Claims{}, fmt.Errorf("%w: alg %q does not match key", ErrInvalidSignature, h.Alg)
+	}
+
+	if err := verifySignature(h.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return Claims{}, err
+	}
+
+	claims, err := ParseUnverified(token)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	if err := v.validateClaims(claims, time.Now()); err != nil {
+		return Claims{}, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) validateClaims(claims Claims, now time.Time) error {
+	exp, ok := claims.Expiry()
+	if !ok {
+		return fmt.Errorf("%w: exp claim is required", ErrTokenExpired)
+	}
+	if now.After(exp.Add(leeway)) {
+		return ErrTokenExpired
+	}
+
+	if claims.NotBefore != 0 && now.Add(leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return ErrTokenNotYetValid
+	}
+
+	if claims.Issuer != v.issuer {
+		return ErrInvalidIssuer
+	}
+
+	if !slices.Contains(claims.Audience, v.audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func hashFor(alg string) (crypto.Hash, func() hash.Hash, bool) {
+	switch alg[len(alg)-3:] {
+	case "256":
+		return crypto.SHA256, sha256.New, true
+	case "384":
+		return crypto.SHA384, sha512.New384, true
+	case "512":
+		return crypto.SHA512, sha512.New, true
+	default:
+		return 0, nil, false
+	}
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput, signature []byte) error {
+	if alg == "EdDSA" {
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(pub, signingInput, signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	if len(alg) != 5 {
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidSignature, alg)
+	}
+
+	cryptoHash, newHash, ok := hashFor(alg)
+	if !ok {
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidSignature, alg)
+	}
+
+	h := newHash()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok || rsa.VerifyPKCS1v15(pub, cryptoHash, digest, signature) != nil {
+			return ErrInvalidSignature
+		}
+	case "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok || rsa.VerifyPSS(pub, cryptoHash, digest, signature, nil) != nil {
+			return ErrInvalidSignature
+		}
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrInvalidSignature
+		}
+
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return ErrInvalidSignature
+		}
+	default:
+		// HS* и none намеренно не поддерживаются: gateway не должен знать общий секрет
+		return fmt.Errorf("%w: unsupported alg %q", ErrInvalidSignature, alg)
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testIssuer   = "auth-service"
+	testAudience = "api-gateway"
+)
+
+type testKeys struct {
+	rsa     *rsa.PrivateKey
+	ec      *ecdsa.PrivateKey
+	ed      ed25519.PrivateKey
+	rsaJWK  JWK
+	ecJWK   JWK
+	edJWK   JWK
+	otherEC *ecdsa.PrivateKey
+}
+
+var (
+	keysOnce   sync.Once
+	sharedKeys testKeys
+)
+
+// newTestKeys создаёт ключи один раз на пакет: генерация RSA заметно медленная
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	keysOnce.Do(func() {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(err)
+		}
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		otherEC, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+		edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			panic(err)
+		}
+
+		enc := base64.RawURLEncoding
+		sharedKeys = testKeys{
+			rsa:     rsaKey,
+			ec:      ecKey,
+			ed:      edKey,
+			otherEC: otherEC,
+			rsaJWK: JWK{Kid: "rsa", Kty: "RSA", Alg: "RS256", Use: "sig",
+				N: enc.EncodeToString(rsaKey.N.Bytes()), E: enc.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes())},
+			ecJWK: JWK{Kid: "ec", Kty: "EC", Alg: "ES256", Crv: "P-256",
+				X: enc.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+				Y: enc.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32)))},
+			// Ключ без alg: алгоритм берётся из заголовка и сверяется с типом ключа
+			edJWK: JWK{Kid: "ed", Kty: "OKP", Crv: "Ed25519", X: enc.EncodeToString(edPub)},
+		}
+	})
+
+	return sharedKeys
+}
+
+// signToken подписывает claims ключом key алгоритмом alg; для "none" и HS256 подпись произвольная
+func signToken(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	enc := base64.RawURLEncoding
+	h, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	p, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+
+	signingInput := enc.EncodeToString(h) + "." + enc.EncodeToString(p)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case "PS256":
+		signature, err = rsa.SignPSS(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:], nil)
+	case "ES256":
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, key.(*ecdsa.PrivateKey), digest[:])
+		if err == nil {
+			signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	case "EdDSA":
+		signature = ed25519.Sign(key.(ed25519.PrivateKey), []byte(signingInput))
+	default:
+		signature = []byte("signature")
+	}
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	return signingInput + "." + enc.EncodeToString(signature)
+}
+
+// staticSource отдаёт заданные ключи и считает запросы
+type staticSource struct {
+	mu      sync.Mutex
+	keys    []JWK
+	err     error
+	delay   time.Duration
+	fetches atomic.Int32
+}
+
+func (s *staticSource) set(keys []JWK, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys, s.err = keys, err
+}
+
+func (s *staticSource) FetchKeys(context.Context) ([]JWK, error) {
+	s.fetches.Add(1)
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keys, s.err
+}
+
+func validClaims() map[string]any {
+	now := time.Now()
+	return map[string]any{
+		"sub": "42",
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": now.Add(time.Hour).Unix(),
+		"iat": now.Unix(),
+		"jti": "token-1",
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	res := make(map[string]any, len(claims))
+	for k, v := range claims {
+		res[k] = v
+	}
+	if value == nil {
+		delete(res, key)
+	} else {
+		res[key] = value
+	}
+
+	return res
+}
+
+func TestVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	now := time.Now()
+
+	valid := signToken(t, "RS256", "rsa", keys.rsa, validClaims())
+	parts := strings.Split(valid, ".")
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1","roles":["admin"]}`))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "RS256", token: valid},
+		{name: "ES256", token: signToken(t, "ES256", "ec", keys.ec, validClaims())},
+		{name: "EdDSA key without alg", token: signToken(t, "EdDSA", "ed", keys.ed, validClaims())},
+		{
+			name:  "audience list",
+			token: signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "aud", []string{"other", testAudience})),
+		},
+		{
+			name:  "expired within leeway",
+			token: signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "exp", now.Add(-leeway/2).Unix())),
+		},
+		{
+			name:  "nbf within leeway",
+			token: signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "nbf", now.Add(leeway/2).Unix())),
+		},
+		{
+			name:    "tampered payload",
+			token:   parts[0] + "." + forgedPayload + "." + parts[2],
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "signed by another key",
+			token:   signToken(t, "ES256", "ec", keys.otherEC, validClaims()),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "alg differs from key alg",
+			token:   signToken(t, "PS256", "rsa", keys.rsa, validClaims()),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "alg none",
+			token:   signToken(t, "none", "ed", nil, validClaims()),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "HS256 is not accepted",
+			token:   signToken(t, "HS256", "ed", nil, validClaims()),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "alg for another key type",
+			token:   signToken(t, "RS256", "ed", keys.rsa, validClaims()),
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "expired beyond leeway",
+			token:   signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "exp", now.Add(-2*leeway).Unix())),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name:    "missing exp",
+			token:   signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "exp", nil)),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name:    "nbf beyond leeway",
+			token:   signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "nbf", now.Add(2*leeway).Unix())),
+			wantErr: ErrTokenNotYetValid,
+		},
+		{
+			name:    "wrong issuer",
+			token:   signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "iss", "evil")),
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name:    "wrong audience",
+			token:   signToken(t, "RS256", "rsa", keys.rsa, with(validClaims(), "aud", "portfolio-service")),
+			wantErr: ErrInvalidAudience,
+		},
+		{
+			name:    "unknown kid",
+			token:   signToken(t, "RS256", "missing", keys.rsa, validClaims()),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "two parts",
+			token:   parts[0] + "." + parts[1],
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "header not base64",
+			token:   "!!." + parts[1] + "." + parts[2],
+			wantErr: ErrMalformedToken,
+		},
+	}
+
+	source := &staticSource{keys: []JWK{keys.rsaJWK, keys.ecJWK, keys.edJWK}}
+	keySet := NewKeySet(source, time.Hour)
+	if err := keySet.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	verifier := NewVerifier(keySet, testIssuer, testAudience)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && claims.Subject != "42" {
+				t.Errorf("Verify() subject = %q, want 42", claims.Subject)
+			}
+		})
+	}
+}
+
+func TestKeySetUnknownKid(t *testing.T) {
+	keys := newTestKeys(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// lastAttemptAgo — давность последнего обновления перед запросом
+		lastAttemptAgo time.Duration
+		rotated        []JWK
+		rotateErr      error
+		wantFetches    int32
+		wantErr        error
+	}{
+		{
+			name:           "rotated key is fetched on demand",
+			lastAttemptAgo: time.Minute,
+			rotated:        []JWK{keys.rsaJWK, keys.ecJWK},
+			wantFetches:    1,
+		},
+		{
+			name:           "refresh is rate limited",
+			lastAttemptAgo: time.Second,
+			rotated:        []JWK{keys.rsaJWK, keys.ecJWK},
+			wantErr:        ErrUnknownKey,
+		},
+		{
+			name:           "key still unknown after refresh",
+			lastAttemptAgo: time.Minute,
+			rotated:        []JWK{keys.rsaJWK},
+			wantFetches:    1,
+			wantErr:        ErrUnknownKey,
+		},
+		{
+			name:           "source unavailable",
+			lastAttemptAgo: time.Minute,
+			rotateErr:      errors.New("connection refused"),
+			wantFetches:    1,
+			wantErr:        ErrKeysUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &staticSource{keys: []JWK{keys.rsaJWK}}
+			keySet := NewKeySet(source, time.Hour)
+			if err := keySet.Refresh(ctx); err != nil {
+				t.Fatalf("Refresh: %v", err)
+			}
+			keySet.lastAttempt = time.Now().Add(-tt.lastAttemptAgo)
+
+			source.set(tt.rotated, tt.rotateErr)
+			source.fetches.Store(0)
+
+			_, alg, err := keySet.Key(ctx, "ec")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Key() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && alg != "ES256" {
+				t.Errorf("Key() alg = %q, want ES256", alg)
+			}
+			if got := source.fetches.Load(); got != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", got, tt.wantFetches)
+			}
+
+			// Известный ключ не требует обращения к источнику даже после неудачного обновления
+			if _, _, err := keySet.Key(ctx, "rsa"); err != nil {
+				t.Errorf("Key(rsa) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestKeySetConcurrentUnknownKid(t *testing.T) {
+	keys := newTestKeys(t)
+	ctx := context.Background()
+
+	source := &staticSource{keys: []JWK{keys.rsaJWK}, delay: 20 * time.Millisecond}
+	keySet := NewKeySet(source, time.Hour)
+	if err := keySet.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	keySet.lastAttempt = time.Now().Add(-time.Minute)
+	source.fetches.Store(0)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = keySet.Key(ctx, "unknown")
+		}()
+	}
+	wg.Wait()
+
+	// Запросы, дождавшиеся чужого обновления под refreshMu, не обновляют набор повторно
+	if got := source.fetches.Load(); got != 1 {
+		t.Errorf("fetches = %d, want 1", got)
+	}
+}
+
+func TestJWKPublicKey(t *testing.T) {
+	keys := newTestKeys(t)
+
+	offCurve := keys.ecJWK
+	offCurve.Y = offCurve.X
+
+	tests := []struct {
+		name    string
+		jwk     JWK
+		wantErr bool
+	}{
+		{name: "RSA", jwk: keys.rsaJWK},
+		{name: "EC", jwk: keys.ecJWK},
+		{name: "Ed25519", jwk: keys.edJWK},
+		{name: "EC point off curve", jwk: offCurve, wantErr: true},
+		{name: "unsupported curve", jwk: JWK{Kty: "EC", Crv: "secp256k1"}, wantErr: true},
+		{name: "short Ed25519 key", jwk: JWK{Kty: "OKP", Crv: "Ed25519", X: "AAAA"}, wantErr: true},
+		{name: "symmetric key", jwk: JWK{Kty: "oct"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.jwk.PublicKey()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PublicKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
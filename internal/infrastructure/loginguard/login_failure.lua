@@ -0,0 +1,30 @@
+-- KEYS[1] - счётчик неудачных попыток
+-- KEYS[2] - номер блокировки (для экспоненциального роста)
+-- KEYS[3] - ключ блокировки
+-- ARGV[1] - maxFailures, ARGV[2] - окно подсчёта (ms)
+-- ARGV[3] - базовая блокировка (ms), ARGV[4] - максимальная блокировка (ms)
+-- ARGV[5] - время жизни номера блокировки (ms)
+
+local failures = redis.call("INCR", KEYS[1])
+if failures == 1 then
+    redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+
+if failures < tonumber(ARGV[1]) then
+    return 0
+end
+
+redis.call("DEL", KEYS[1])
+
+local level = redis.call("INCR", KEYS[2])
+redis.call("PEXPIRE", KEYS[2], ARGV[5])
+
+local lockout = tonumber(ARGV[3]) * 2 ^ (level - 1)
+if lockout > tonumber(ARGV[4]) then
+    lockout = tonumber(ARGV[4])
+end
+lockout = math.floor(lockout)
+
+redis.call("SET", KEYS[3], 1, "PX", lockout)
+
+return lockout
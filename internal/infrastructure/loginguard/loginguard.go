@@ -0,0 +1,131 @@
+package loginguard
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/metrics"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+//go:embed login_failure.lua
+var luaLoginFailure string
+
+const (
+	ScopeAccount = "account"
+	ScopeIP      = "ip"
+
+	keyPrefix = "auth:login:"
+)
+
+var _ auth.LoginGuardContract = (*LoginGuard)(nil)
+
+// Limits — пороги для одного измерения (аккаунт или IP).
+type Limits struct {
+	MaxFailures int
+	Window      time.Duration
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+}
+
+type LoginGuard struct {
+	client  *redis.Client
+	script  *redis.Script
+	account Limits
+	ip      Limits
+}
+
+func NewLoginGuard(client *redis.Client, account, ip Limits) *LoginGuard {
+	return &LoginGuard{
+		client:  client,
+		script:  redis.NewScript(luaLoginFailure),
+		account: account,
+		ip:      ip,
+	}
+}
+
+// Имя аккаунта хешируется, чтобы не хранить email в ключах Redis
+func accountID(account string) string {
+	sum := sha256.Sum256([]byte(account))
+	return hex.EncodeToString(sum[:])
+}
+
+func failuresKey(scope, id string) string {
+	return keyPrefix + "fail:" + scope + ":" + id
+}
+
+func levelKey(scope, id string) string {
+	return keyPrefix + "level:" + scope + ":" + id
+}
+
+func lockKey(scope, id string) string {
+	return keyPrefix + "lock:" + scope + ":" + id
+}
+
+func (g *LoginGuard) Check(ctx context.Context, account, ip string) (time.Duration, error) {
+	pipe := g.client.Pipeline()
+	accountTTL := pipe.PTTL(ctx, lockKey(ScopeAccount, accountID(account)))
+	ipTTL := pipe.PTTL(ctx, lockKey(ScopeIP, ip))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to check login lock: %w", err)
+	}
+
+	// PTTL возвращает отрицательное значение, если ключа нет
+	return max(accountTTL.Val(), ipTTL.Val(), 0), nil
+}
+
+func (g *LoginGuard) RegisterFailure(ctx context.Context, account, ip string) (time.Duration, error) {
+	accountLockout, err := g.registerFailure(ctx, ScopeAccount, accountID(account), g.account)
+	if err != nil {
+		return 0, err
+	}
+
+	ipLockout, err := g.registerFailure(ctx, ScopeIP, ip, g.ip)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(accountLockout, ipLockout), nil
+}
+
+func (g *LoginGuard) registerFailure(ctx context.Context, scope, id string, limits Limits) (time.Duration, error) {
+	// Номер блокировки живёт дольше самой блокировки, чтобы повторные блокировки росли
+	levelTTL := max(limits.MaxLockout*2, limits.Window)
+
+	res, err := g.script.Run(ctx, g.client,
+		[]string{failuresKey(scope, id), levelKey(scope, id), lockKey(scope, id)},
+		limits.MaxFailures,
+		limits.Window.Milliseconds(),
+		limits.BaseLockout.Milliseconds(),
+		limits.MaxLockout.Milliseconds(),
+		levelTTL.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to register login failure: %w", err)
+	}
+
+	if res == 0 {
+		return 0, nil
+	}
+
+	metrics.IncLoginLockout(scope)
+
+	return time.Duration(res) * time.Millisecond, nil
+}
+
+// Reset сбрасывает счётчики аккаунта после успешного входа.
+// Счётчик IP не сбрасывается, чтобы один валидный аккаунт не открывал перебор остальных.
+func (g *LoginGuard) Reset(ctx context.Context, account string) error {
+	id := accountID(account)
+
+	if err := g.client.Del(ctx, failuresKey(ScopeAccount, id), levelKey(ScopeAccount, id)).Err(); err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
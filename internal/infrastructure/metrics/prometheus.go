@@ -30,13 +30,36 @@ var (
 		},
 	)
 
+	verifyCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "auth_verify_cache_hits_total",
+			Help: "Total number of token verifications served from cache",
+		},
+	)
+
+	verifyCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "auth_verify_cache_misses_total",
+			Help: "Total number of token verifications that missed the cache",
+		},
+	)
+
+	loginLockouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_login_lockouts_total",
+			Help: "Total number of login lockouts after repeated failed attempts",
+		},
+		[]string{"scope"},
+	)
+
 	// Глобальный registry
 	Registry = prometheus.NewRegistry()
 )
 
 // Инициализация — один раз при старте приложения
 func InitMetrics() {
-	Registry.MustRegister(httpRequests, httpDuration, limitedRequests)
+	Registry.MustRegister(httpRequests, httpDuration, limitedRequests, verifyCacheHits, verifyCacheMisses,
+		loginLockouts)
 }
 
 // Инкремент запросов
@@ -53,3 +76,18 @@ func ObserveDuration(path string, d time.Duration) {
 func IncRateLimited() {
 	limitedRequests.Inc()
 }
+
+// Попадание в кеш проверки токена
+func IncVerifyCacheHit() {
+	verifyCacheHits.Inc()
+}
+
+// Промах кеша проверки токена
+func IncVerifyCacheMiss() {
+	verifyCacheMisses.Inc()
+}
+
+// Блокировка входа (scope: account или ip)
+func IncLoginLockout(scope string) {
+	loginLockouts.WithLabelValues(scope).Inc()
+}
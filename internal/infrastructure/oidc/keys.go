@@ -0,0 +1,45 @@
+package oidc
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+var _ jwt.KeySource = (*HTTPKeySource)(nil)
+
+// HTTPKeySource загружает JWKS провайдера по jwks_uri.
+type HTTPKeySource struct {
+	httpClient *http.Client
+	url        string
+}
+
+func NewHTTPKeySource(httpClient *http.Client, url string) *HTTPKeySource {
+	return &HTTPKeySource{httpClient: httpClient, url: url}
+}
+
+func (s *HTTPKeySource) FetchKeys(ctx context.Context) ([]jwt.JWK, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build jwks request: %w", err)
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: unexpected status %d", res.StatusCode)
+	}
+
+	var set jwt.JWKSet
+	if err := json.NewDecoder(res.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	return set.Keys, nil
+}
@@ -0,0 +1,198 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxResponseSize ограничивает ответы провайдера, чтобы не читать в память произвольный объём
+const maxResponseSize = 1 << 20
+
+var _ auth.OIDCProviderContract = (*Provider)(nil)
+
+// Discovery — нужная gateway часть /.well-known/openid-configuration.
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover загружает метаданные провайдера и проверяет, что issuer совпадает с настроенным.
+func Discover(ctx context.Context, httpClient *http.Client, issuerURL string) (Discovery, error) {
+	issuerURL = strings.TrimSuffix(issuerURL, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return Discovery{}, fmt.Errorf("failed to build discovery request: %w", err)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return Discovery{}, fmt.Errorf("failed to fetch oidc discovery: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Discovery{}, fmt.Errorf("failed to fetch oidc discovery: unexpected status %d", res.StatusCode)
+	}
+
+	var discovery Discovery
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&discovery); err != nil {
+		return Discovery{}, fmt.Errorf("failed to decode oidc discovery: %w", err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuerURL {
+		return Discovery{}, fmt.Errorf("oidc discovery issuer %q does not match %q", discovery.Issuer, issuerURL)
+	}
+
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return Discovery{}, errors.New("oidc discovery is missing required endpoints")
+	}
+
+	return discovery, nil
+}
+
+type ProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type Provider struct {
+	cfg        ProviderConfig
+	discovery  Discovery
+	httpClient *http.Client
+	verifier   *jwt.Verifier
+}
+
+// NewProvider ожидает verifier, настроенный на issuer провайдера и client_id в качестве audience.
+func NewProvider(cfg ProviderConfig, discovery Discovery, httpClient *http.Client, verifier *jwt.Verifier) *Provider {
+	return &Provider{
+		cfg:        cfg,
+		discovery:  discovery,
+		httpClient: httpClient,
+		verifier:   verifier,
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return p.discovery.AuthorizationEndpoint + separator + params.Encode()
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (auth.ExternalIdentity, error) {
+	idToken, err := p.exchangeCode(ctx, code, codeVerifier)
+	if err != nil {
+		return auth.ExternalIdentity{}, err
+	}
+
+	claims, err := p.verifier.Verify(ctx, idToken)
+	if err != nil {
+		// Недоступность JWKS — сбой инфраструктуры, а не поддельный токен
+		if errors.Is(err, jwt.ErrKeysUnavailable) {
+			return auth.ExternalIdentity{}, err
+		}
+
+		return auth.ExternalIdentity{}, fmt.Errorf("%w: %v", auth.ErrInvalidIDToken, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return auth.ExternalIdentity{}, fmt.Errorf("%w: nonce mismatch", auth.ErrInvalidIDToken)
+	}
+
+	if claims.Subject == "" {
+		return auth.ExternalIdentity{}, fmt.Errorf("%w: sub claim is required", auth.ErrInvalidIDToken)
+	}
+
+	return auth.ExternalIdentity{
+		Provider:      p.cfg.Name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Username:      claims.PreferredUsername,
+		Name:          claims.Name,
+	}, nil
+}
+
+func (p *Provider) exchangeCode(ctx context.Context, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	// Публичные клиенты работают только с PKCE, конфиденциальные — ещё и с client_secret_basic
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call token endpoint: %w", err)
+	}
+	defer res.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	// 4xx — код просрочен, уже использован или не прошёл PKCE; 5xx — сбой провайдера
+	if res.StatusCode >= 400 && res.StatusCode < 500 {
+		return "", fmt.Errorf("%w: %s %s", auth.ErrOIDCCodeRejected, body.Error, body.ErrorDescription)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d: %s %s", res.StatusCode, body.Error, body.ErrorDescription)
+	}
+
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: token response has no id_token", auth.ErrInvalidIDToken)
+	}
+
+	return body.IDToken, nil
+}
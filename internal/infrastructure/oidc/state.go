@@ -0,0 +1,71 @@
+package oidc
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const stateKeyPrefix = "auth:oidc:state:"
+
+var _ auth.OIDCStateStoreContract = (*StateStore)(nil)
+
+type StateStore struct {
+	client *redis.Client
+}
+
+func NewStateStore(client *redis.Client) *StateStore {
+	return &StateStore{client: client}
+}
+
+type storedState struct {
+	Nonce          string `json:"nonce"`
+	CodeVerifier   string `json:"code_verifier"`
+	RedirectAfter  string `json:"redirect_after"`
+	BrowserBinding string `json:"browser_binding"`
+}
+
+func (s *StateStore) Save(ctx context.Context, state string, data auth.OIDCState, ttl time.Duration) error {
+	raw, err := json.Marshal(storedState{
+		Nonce:          data.Nonce,
+		CodeVerifier:   data.CodeVerifier,
+		RedirectAfter:  data.RedirectAfter,
+		BrowserBinding: data.BrowserBindingHash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal oidc state: %w", err)
+	}
+
+	if err := s.client.Set(ctx, stateKeyPrefix+state, raw, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save oidc state: %w", err)
+	}
+
+	return nil
+}
+
+// Take удаляет state при чтении, чтобы callback нельзя было повторить
+func (s *StateStore) Take(ctx context.Context, state string) (*auth.OIDCState, error) {
+	raw, err := s.client.GetDel(ctx, stateKeyPrefix+state).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oidc state: %w", err)
+	}
+
+	var stored storedState
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal oidc state: %w", err)
+	}
+
+	return &auth.OIDCState{
+		Nonce:              stored.Nonce,
+		CodeVerifier:       stored.CodeVerifier,
+		RedirectAfter:      stored.RedirectAfter,
+		BrowserBindingHash: stored.BrowserBinding,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
@@ -44,6 +45,55 @@ func (c *portfolioServiceClient) CreateNewPortfolio(ctx context.Context, name st
 	}, nil
 }
 
+func (c *portfolioServiceClient) UpdatePortfolio(ctx context.Context, portfolioId int,
+	update portfolio.PortfolioUpdate) (portfolio.Portfolio, error) {
+	log := logger.FromContext(ctx)
+
+	req := &portfoliopb.UpdatePortfolioRequest{Id: int32(portfolioId)}
+	if update.Name != nil {
+		req.Name = wrapperspb.String(*update.Name)
+	}
+	if update.IsPublic != nil {
+		req.IsPublic = wrapperspb.Bool(*update.IsPublic)
+	}
+
+	res, err := c.GRPCClient.UpdatePortfolio(ctx, req)
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to update portfolio via gRPC",
+			zap.Int("portfolio_id", portfolioId),
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return portfolio.Portfolio{}, err
+	}
+
+	return portfolio.Portfolio{
+		Id:       res.Id,
+		Name:     res.Name,
+		IsPublic: res.IsPublic.GetValue(),
+	}, nil
+}
+
+func (c *portfolioServiceClient) DeletePortfolio(ctx context.Context, portfolioId int) error {
+	log := logger.FromContext(ctx)
+
+	_, err := c.GRPCClient.DeletePortfolio(ctx, &portfoliopb.DeletePortfolioRequest{Id: int32(portfolioId)})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to delete portfolio via gRPC",
+			zap.Int("portfolio_id", portfolioId),
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return err
+	}
+
+	return nil
+}
+
 func (c *portfolioServiceClient) GetPortfolioContentById(ctx context.Context, portfolioID int) (portfolio.PortfolioContent, error) {
 	log := logger.FromContext(ctx)
 
@@ -124,13 +174,17 @@ func (c *portfolioServiceClient) GetAllPortfolios(ctx context.Context) ([]portfo
 	return portfolios, nil
 }
 
-func (c *portfolioServiceClient) GetPortfolioHistory(ctx context.Context, id, page, pageSize int32) (portfolio.PortfolioHistory, error) {
+func (c *portfolioServiceClient) GetPortfolioHistory(ctx context.Context,
+	query portfolio.HistoryQuery) (portfolio.PortfolioHistory, error) {
 	log := logger.FromContext(ctx)
 
 	res, err := c.GRPCClient.GetPortfolioHistory(ctx, &portfoliopb.GetPortfolioHistoryRequest{
-		Id:       id,
-		Page:     page,
-		PageSize: pageSize,
+		Id:       int32(query.PortfolioId),
+		PageSize: int32(query.PageSize),
+		From:     timestamppb.New(query.From),
+		To:       timestamppb.New(query.To),
+		Symbols:  query.Symbols,
+		Cursor:   query.Cursor,
 	})
 	if err != nil {
 		st, _ := status.FromError(err)
@@ -142,7 +196,13 @@ func (c *portfolioServiceClient) GetPortfolioHistory(ctx context.Context, id, pa
 		return portfolio.PortfolioHistory{}, err
 	}
 
-	portfolioHistory := mapper.MapProtoToDomainHistory(res.History)
+	portfolioHistory, err := mapper.MapProtoToDomainHistory(res.History)
+	if err != nil {
+		log.Error("failed to map portfolio history", zap.Int("portfolio_id", query.PortfolioId), zap.Error(err))
+		return portfolio.PortfolioHistory{}, err
+	}
+
+	portfolioHistory.NextCursor = res.NextCursor
 	return portfolioHistory, nil
 }
 
@@ -163,3 +223,76 @@ func (c *portfolioServiceClient) GetPublicPortfolios(ctx context.Context, userId
 	portfolios := mapper.MapProtoToDomainPublicPortfolios(res.Portfolios)
 	return portfolios, nil
 }
+
+func (c *portfolioServiceClient) GetPortfolioProfit(ctx context.Context, portfolioId int) ([]portfolio.AssetProfit, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.GRPCClient.GetPortfolioProfit(ctx, &portfoliopb.GetPortfolioProfitRequest{Id: int64(portfolioId)})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to get portfolio profit via gRPC",
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return nil, err
+	}
+
+	profits := mapper.MapProtoToDomainAssetProfits(res.Assets)
+	return profits, nil
+}
+
+func (c *portfolioServiceClient) AddTransaction(ctx context.Context, tx portfolio.Transaction) (portfolio.Transaction, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.GRPCClient.AddTransaction(ctx, &portfoliopb.AddTransactionRequest{
+		PortfolioId: int32(tx.PortfolioId),
+		Type:        mapper.MapDomainToProtoTransactionType(tx.Type),
+		Symbol:      tx.Symbol,
+		Amount:      tx.Amount,
+		Price:       tx.Price,
+		Fee:         tx.Fee,
+		ExecutedAt:  timestamppb.New(tx.ExecutedAt),
+		Note:        tx.Note,
+	})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to add transaction via gRPC",
+			zap.Int("portfolio_id", tx.PortfolioId),
+			zap.String("symbol", tx.Symbol),
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return portfolio.Transaction{}, err
+	}
+
+	return mapper.MapProtoToDomainTransaction(res), nil
+}
+
+func (c *portfolioServiceClient) ListTransactions(ctx context.Context,
+	query portfolio.TransactionQuery) (portfolio.TransactionPage, error) {
+	log := logger.FromContext(ctx)
+
+	res, err := c.GRPCClient.ListTransactions(ctx, &portfoliopb.ListTransactionsRequest{
+		PortfolioId: int32(query.PortfolioId),
+		Symbol:      query.Symbol,
+		PageSize:    int32(query.PageSize),
+		Cursor:      query.Cursor,
+	})
+	if err != nil {
+		st, _ := status.FromError(err)
+		log.Error("failed to list transactions via gRPC",
+			zap.Int("portfolio_id", query.PortfolioId),
+			zap.String("grpc_code", st.Code().String()),
+			zap.Error(err),
+		)
+
+		return portfolio.TransactionPage{}, err
+	}
+
+	return portfolio.TransactionPage{
+		Transactions: mapper.MapProtoToDomainTransactions(res.Transactions),
+		NextCursor:   res.NextCursor,
+	}, nil
+}
@@ -3,6 +3,8 @@ package mapper
 import (
 	portfoliopb "crypto_analyzer-api_gateway/gen/go/portfolio"
 	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"fmt"
+	"time"
 )
 
 func MapPortfoliosToDomainPortfolios(portfolios []*portfoliopb.AllUserPortfolio) []portfolio.Portfolio {
@@ -19,7 +21,8 @@ func MapPortfoliosToDomainPortfolios(portfolios []*portfoliopb.AllUserPortfolio)
 	return portfoliosSlice
 }
 
-func MapProtoToDomainHistory(portfolioHistory map[string]*portfoliopb.PricePoints) portfolio.PortfolioHistory {
+// MapProtoToDomainHistory разбирает строковые метки времени Portfolio Service (RFC 3339).
+func MapProtoToDomainHistory(portfolioHistory map[string]*portfoliopb.PricePoints) (portfolio.PortfolioHistory, error) {
 	history := portfolio.PortfolioHistory{History: make(map[string][]portfolio.PricePoint, len(portfolioHistory))}
 
 	for key, v := range portfolioHistory {
@@ -30,14 +33,19 @@ func MapProtoToDomainHistory(portfolioHistory map[string]*portfoliopb.PricePoint
 		history.History[key] = make([]portfolio.PricePoint, 0, len(v.Points))
 
 		for _, m := range v.Points {
+			ts, err := time.Parse(time.RFC3339Nano, m.Timestamp)
+			if err != nil {
+				return portfolio.PortfolioHistory{}, fmt.Errorf("invalid timestamp %q for %s: %w", m.Timestamp, key, err)
+			}
+
 			history.History[key] = append(history.History[key], portfolio.PricePoint{
-				Timestamp: m.Timestamp,
+				Timestamp: ts.UTC(),
 				Value:     m.Value,
 			})
 		}
 	}
 
-	return history
+	return history, nil
 }
 
 func MapProtoToDomainPublicPortfolios(portfolios []*portfoliopb.PublicPortfolio) []portfolio.PublicPortfolio {
@@ -53,3 +61,64 @@ func MapProtoToDomainPublicPortfolios(portfolios []*portfoliopb.PublicPortfolio)
 
 	return publicPortfolios
 }
+
+func MapProtoToDomainAssetProfits(assets []*portfoliopb.AssetProfit) []portfolio.AssetProfit {
+	profits := make([]portfolio.AssetProfit, 0, len(assets))
+
+	for _, v := range assets {
+		profits = append(profits, portfolio.AssetProfit{
+			Symbol:       v.Symbol,
+			Amount:       v.Amount,
+			Invested:     v.Invested,
+			CurrentPrice: v.CurrentPrice,
+			CurrentValue: v.CurrentValue,
+			Profit:       v.Profit,
+		})
+	}
+
+	return profits
+}
+
+var transactionTypes = map[string]portfoliopb.TransactionType{
+	portfolio.TransactionBuy:         portfoliopb.TransactionType_TRANSACTION_TYPE_BUY,
+	portfolio.TransactionSell:        portfoliopb.TransactionType_TRANSACTION_TYPE_SELL,
+	portfolio.TransactionTransferIn:  portfoliopb.TransactionType_TRANSACTION_TYPE_TRANSFER_IN,
+	portfolio.TransactionTransferOut: portfoliopb.TransactionType_TRANSACTION_TYPE_TRANSFER_OUT,
+}
+
+func MapDomainToProtoTransactionType(txType string) portfoliopb.TransactionType {
+	return transactionTypes[txType]
+}
+
+func MapProtoToDomainTransaction(tx *portfoliopb.Transaction) portfolio.Transaction {
+	res := portfolio.Transaction{
+		Id:          tx.Id,
+		PortfolioId: int(tx.PortfolioId),
+		Symbol:      tx.Symbol,
+		Amount:      tx.Amount,
+		Price:       tx.Price,
+		Fee:         tx.Fee,
+		ExecutedAt:  tx.ExecutedAt.AsTime(),
+		Note:        tx.Note,
+	}
+
+	for name, t := range transactionTypes {
+		if t == tx.Type {
+			res.Type = name
+		}
+	}
+
+	return res
+}
+
+func MapProtoToDomainTransactions(txs []*portfoliopb.Transaction) []portfolio.Transaction {
+	res := make([]portfolio.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		res = append(res, MapProtoToDomainTransaction(tx))
+	}
+
+	return res
+}
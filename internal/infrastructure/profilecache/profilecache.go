@@ -0,0 +1,81 @@
+package profilecache
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	keyPrefix = "user:profile:"
+	// notFoundValue отмечает закешированное отсутствие пользователя
+	notFoundValue = "-"
+	// Отсутствие кешируется коротко, чтобы новый пользователь быстро стал доступен по ссылке
+	maxNotFoundTTL = time.Minute
+)
+
+var _ portfolio.ProfileCacheContract = (*ProfileCache)(nil)
+
+// ProfileCache хранит профиль по username. Переименование происходит в Auth Service и в gateway
+// не видно, поэтому ttl — это и срок, в течение которого старый username ведёт к прежнему владельцу;
+// он должен быть коротким.
+// Ключ — username в том виде, в каком он передан в LookupUserByUsername: правила сравнения имён
+// (регистр и т.п.) определяет Auth Service, и кеш не должен отвечать за него по своим правилам.
+type ProfileCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewProfileCache(client *redis.Client, ttl time.Duration) *ProfileCache {
+	return &ProfileCache{client: client, ttl: ttl}
+}
+
+func (c *ProfileCache) key(username string) string {
+	return keyPrefix + username
+}
+
+func (c *ProfileCache) Get(ctx context.Context, username string) (*portfolio.PublicProfile, error) {
+	data, err := c.client.Get(ctx, c.key(username)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached profile: %w", err)
+	}
+
+	if string(data) == notFoundValue {
+		return nil, portfolio.ErrUserNotFound
+	}
+
+	var profile portfolio.PublicProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode cached profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+func (c *ProfileCache) Set(ctx context.Context, username string, profile portfolio.PublicProfile) error {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	if err := c.client.Set(ctx, c.key(username), data, c.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache profile: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ProfileCache) SetNotFound(ctx context.Context, username string) error {
+	if err := c.client.Set(ctx, c.key(username), notFoundValue, min(c.ttl, maxNotFoundTTL)).Err(); err != nil {
+		return fmt.Errorf("failed to cache missing profile: %w", err)
+	}
+
+	return nil
+}
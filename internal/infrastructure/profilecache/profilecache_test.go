@@ -0,0 +1,45 @@
+package profilecache
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"testing"
+	"time"
+)
+
+func TestKeyIsExactUsername(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	cache := NewProfileCache(client, time.Hour)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "Alice", portfolio.PublicProfile{UserId: "1", Username: "Alice"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := cache.SetNotFound(ctx, "bob"); err != nil {
+		t.Fatalf("SetNotFound: %v", err)
+	}
+
+	// Другое написание — отдельный вопрос к Auth Service, а не ответ из кеша
+	for _, username := range []string{"alice", "ALICE", "Bob"} {
+		got, err := cache.Get(ctx, username)
+		if err != nil || got != nil {
+			t.Errorf("Get(%q) = %+v, %v, want a cache miss", username, got, err)
+		}
+	}
+
+	if got, err := cache.Get(ctx, "Alice"); err != nil || got == nil || got.UserId != "1" {
+		t.Errorf("Get(Alice) = %+v, %v", got, err)
+	}
+	if _, err := cache.Get(ctx, "bob"); !errors.Is(err, portfolio.ErrUserNotFound) {
+		t.Errorf("Get(bob) error = %v, want %v", err, portfolio.ErrUserNotFound)
+	}
+	if ttl := mr.TTL(keyPrefix + "bob"); ttl != maxNotFoundTTL {
+		t.Errorf("not found ttl = %v, want %v", ttl, maxNotFoundTTL)
+	}
+}
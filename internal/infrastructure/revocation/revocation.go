@@ -0,0 +1,85 @@
+package revocation
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	jtiKeyPrefix  = "auth:revoked:jti:"
+	hashKeyPrefix = "auth:revoked:sha:"
+)
+
+var _ auth.RevocationListContract = (*RevocationList)(nil)
+
+type RevocationList struct {
+	client *redis.Client
+	// maxTTL используется, когда срок действия токена неизвестен, и ограничивает остальные записи
+	maxTTL time.Duration
+}
+
+func NewRevocationList(client *redis.Client, maxTTL time.Duration) *RevocationList {
+	return &RevocationList{client: client, maxTTL: maxTTL}
+}
+
+func (l *RevocationList) ttl(expiresAt time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return l.maxTTL
+	}
+
+	return min(l.maxTTL, time.Until(expiresAt))
+}
+
+func (l *RevocationList) set(ctx context.Context, key string, expiresAt time.Time) error {
+	ttl := l.ttl(expiresAt)
+	if ttl <= 0 {
+		// Токен уже истёк — отзывать нечего
+		return nil
+	}
+
+	if err := l.client.Set(ctx, key, 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	return nil
+}
+
+func (l *RevocationList) RevokeToken(ctx context.Context, token string) error {
+	claims, err := jwt.ParseUnverified(token)
+	if err != nil {
+		return l.set(ctx, hashKeyPrefix+jwt.TokenHash(token), time.Time{})
+	}
+
+	expiresAt, _ := claims.Expiry()
+	if claims.ID != "" {
+		return l.set(ctx, jtiKeyPrefix+claims.ID, expiresAt)
+	}
+
+	return l.set(ctx, hashKeyPrefix+jwt.TokenHash(token), expiresAt)
+}
+
+func (l *RevocationList) RevokeTokenID(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return l.set(ctx, jtiKeyPrefix+tokenID, expiresAt)
+}
+
+func (l *RevocationList) RevokeTokenHash(ctx context.Context, tokenHash string, expiresAt time.Time) error {
+	return l.set(ctx, hashKeyPrefix+tokenHash, expiresAt)
+}
+
+func (l *RevocationList) IsRevoked(ctx context.Context, token string) (bool, error) {
+	keys := []string{hashKeyPrefix + jwt.TokenHash(token)}
+	if claims, err := jwt.ParseUnverified(token); err == nil && claims.ID != "" {
+		keys = append(keys, jtiKeyPrefix+claims.ID)
+	}
+
+	n, err := l.client.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check revocation list: %w", err)
+	}
+
+	return n > 0, nil
+}
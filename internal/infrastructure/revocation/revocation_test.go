@@ -0,0 +1,201 @@
+package revocation
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"testing"
+	"time"
+)
+
+const testMaxTTL = time.Hour
+
+func newTestList(t *testing.T) (*RevocationList, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewRevocationList(client, testMaxTTL), mr
+}
+
+// testToken собирает JWT без настоящей подписи: списку отзыва подпись не важна
+func testToken(t *testing.T, claims map[string]any) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + enc.EncodeToString(payload) + "." + enc.EncodeToString([]byte("sig"))
+}
+
+func TestRevokeToken(t *testing.T) {
+	exp := time.Now().Add(10 * time.Minute)
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		raw     string
+		wantKey string
+		// 0 — запись не создаётся
+		wantTTL time.Duration
+	}{
+		{
+			name:    "by jti until expiry",
+			claims:  map[string]any{"jti": "j1", "exp": exp.Unix()},
+			wantKey: jtiKeyPrefix + "j1",
+			wantTTL: 10 * time.Minute,
+		},
+		{
+			name:    "expiry beyond max ttl is capped",
+			claims:  map[string]any{"jti": "j2", "exp": time.Now().Add(24 * time.Hour).Unix()},
+			wantKey: jtiKeyPrefix + "j2",
+			wantTTL: testMaxTTL,
+		},
+		{
+			name:    "without jti by hash",
+			claims:  map[string]any{"sub": "1", "exp": exp.Unix()},
+			wantTTL: 10 * time.Minute,
+		},
+		{
+			name:    "opaque token by hash for max ttl",
+			raw:     "opaque-token",
+			wantKey: hashKeyPrefix + jwt.TokenHash("opaque-token"),
+			wantTTL: testMaxTTL,
+		},
+		{
+			name:   "expired token is not stored",
+			claims: map[string]any{"jti": "j3", "exp": time.Now().Add(-time.Minute).Unix()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, mr := newTestList(t)
+			ctx := context.Background()
+
+			token := tt.raw
+			if token == "" {
+				token = testToken(t, tt.claims)
+			}
+			if tt.wantKey == "" && tt.wantTTL > 0 {
+				tt.wantKey = hashKeyPrefix + jwt.TokenHash(token)
+			}
+
+			if err := l.RevokeToken(ctx, token); err != nil {
+				t.Fatalf("RevokeToken: %v", err)
+			}
+
+			if tt.wantTTL == 0 {
+				if keys := mr.Keys(); len(keys) != 0 {
+					t.Fatalf("keys = %v, want none", keys)
+				}
+				return
+			}
+
+			if keys := mr.Keys(); len(keys) != 1 || keys[0] != tt.wantKey {
+				t.Fatalf("keys = %v, want [%s]", keys, tt.wantKey)
+			}
+			if ttl := mr.TTL(tt.wantKey); ttl < tt.wantTTL-5*time.Second || ttl > tt.wantTTL {
+				t.Errorf("ttl = %v, want about %v", ttl, tt.wantTTL)
+			}
+
+			revoked, err := l.IsRevoked(ctx, token)
+			if err != nil || !revoked {
+				t.Errorf("IsRevoked() = %v, %v, want true", revoked, err)
+			}
+		})
+	}
+}
+
+func TestIsRevoked(t *testing.T) {
+	exp := time.Now().Add(time.Hour)
+	token := testToken(t, map[string]any{"jti": "j1", "sub": "1", "exp": exp.Unix()})
+
+	tests := []struct {
+		name   string
+		revoke func(ctx context.Context, l *RevocationList) error
+		check  string
+		want   bool
+	}{
+		{
+			name:   "nothing revoked",
+			revoke: func(context.Context, *RevocationList) error { return nil },
+			check:  token,
+		},
+		{
+			name: "revoked by id",
+			revoke: func(ctx context.Context, l *RevocationList) error {
+				return l.RevokeTokenID(ctx, "j1", exp)
+			},
+			check: token,
+			want:  true,
+		},
+		{
+			name: "revoked by hash",
+			revoke: func(ctx context.Context, l *RevocationList) error {
+				return l.RevokeTokenHash(ctx, jwt.TokenHash(token), exp)
+			},
+			check: token,
+			want:  true,
+		},
+		{
+			name: "hash revocation does not reach another token with the same jti",
+			revoke: func(ctx context.Context, l *RevocationList) error {
+				forged := testToken(t, map[string]any{"jti": "j1", "sub": "2"})
+				return l.RevokeTokenHash(ctx, jwt.TokenHash(forged), time.Time{})
+			},
+			check: token,
+		},
+		{
+			name: "other id",
+			revoke: func(ctx context.Context, l *RevocationList) error {
+				return l.RevokeTokenID(ctx, "j2", exp)
+			},
+			check: token,
+		},
+		{
+			name: "malformed token is checked by hash",
+			revoke: func(ctx context.Context, l *RevocationList) error {
+				return l.RevokeTokenHash(ctx, jwt.TokenHash("not-a-jwt"), exp)
+			},
+			check: "not-a-jwt",
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, _ := newTestList(t)
+			ctx := context.Background()
+
+			if err := tt.revoke(ctx, l); err != nil {
+				t.Fatalf("revoke: %v", err)
+			}
+
+			revoked, err := l.IsRevoked(ctx, tt.check)
+			if err != nil {
+				t.Fatalf("IsRevoked: %v", err)
+			}
+			if revoked != tt.want {
+				t.Errorf("IsRevoked() = %v, want %v", revoked, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRevokedRedisDown(t *testing.T) {
+	l, mr := newTestList(t)
+	mr.Close()
+
+	if _, err := l.IsRevoked(context.Background(), "token"); err == nil {
+		t.Error("IsRevoked() error = nil, want error when redis is unavailable")
+	}
+}
@@ -0,0 +1,300 @@
+package session
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	sessionKeyPrefix = "session:"
+	userKeyPrefix    = "session:user:"
+	refreshKeyPrefix = "session:rt:"
+)
+
+var _ auth.SessionStoreContract = (*SessionStore)(nil)
+
+// errUnreadableSession — refresh-токен сессии не расшифровывается
+var errUnreadableSession = errors.New("unreadable session")
+
+// record — сессия в Redis. Access-токен хранится только как jti, хеш и срок действия,
+// refresh-токен — зашифрованным (AES-GCM), чтобы чтение Redis не давало захватить сессию.
+type record struct {
+	Id                   string    `json:"id"`
+	UserId               string    `json:"user_id"`
+	UserAgent            string    `json:"user_agent"`
+	IP                   string    `json:"ip"`
+	CreatedAt            time.Time `json:"created_at"`
+	LastUsedAt           time.Time `json:"last_used_at"`
+	AccessTokenID        string    `json:"access_token_id,omitempty"`
+	AccessTokenHash      string    `json:"access_token_hash"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	RefreshTokenSealed   string    `json:"refresh_token_sealed"`
+}
+
+type SessionStore struct {
+	client *redis.Client
+	// defaultTTL используется, если срок действия refresh-токена неизвестен
+	defaultTTL time.Duration
+	aead       cipher.AEAD
+}
+
+// NewSessionStore выводит ключ AES-256 из encryptionKey (SESSION_ENCRYPTION_KEY).
+func NewSessionStore(client *redis.Client, defaultTTL time.Duration, encryptionKey []byte) (*SessionStore, error) {
+	key := sha256.Sum256(encryptionKey)
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to init session cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init session cipher: %w", err)
+	}
+
+	return &SessionStore{client: client, defaultTTL: defaultTTL, aead: aead}, nil
+}
+
+// seal шифрует refresh-токен; id сессии входит в AAD, запись нельзя переставить в другую сессию
+func (s *SessionStore) seal(sessionId, token string) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := s.aead.Seal(nonce, nonce, []byte(token), []byte(sessionId))
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *SessionStore) open(sessionId, sealed string) (string, error) {
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < s.aead.NonceSize() {
+		return "", errors.New("malformed refresh token")
+	}
+
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	token, err := s.aead.Open(nil, nonce, ciphertext, []byte(sessionId))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt refresh token: %w", err)
+	}
+
+	return string(token), nil
+}
+
+func (s *SessionStore) ttl(refreshToken string) time.Duration {
+	claims, err := jwt.ParseUnverified(refreshToken)
+	if err != nil {
+		return s.defaultTTL
+	}
+
+	exp, ok := claims.Expiry()
+	if !ok {
+		return s.defaultTTL
+	}
+
+	return time.Until(exp)
+}
+
+func (s *SessionStore) get(ctx context.Context, id string) (*auth.Session, error) {
+	data, err := s.client.Get(ctx, sessionKeyPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	session, err := s.decode(data)
+	if errors.Is(err, errUnreadableSession) {
+		// Запись старого формата (с открытыми токенами) или под другим ключом — удаляем
+		if err := s.client.Del(ctx, sessionKeyPrefix+id).Err(); err != nil {
+			return nil, fmt.Errorf("failed to delete unreadable session: %w", err)
+		}
+		return nil, nil
+	}
+
+	return session, err
+}
+
+func (s *SessionStore) decode(data []byte) (*auth.Session, error) {
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+
+	refreshToken, err := s.open(r.Id, r.RefreshTokenSealed)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", errUnreadableSession, r.Id, err)
+	}
+
+	return &auth.Session{
+		Id:                   r.Id,
+		UserId:               r.UserId,
+		UserAgent:            r.UserAgent,
+		IP:                   r.IP,
+		CreatedAt:            r.CreatedAt,
+		LastUsedAt:           r.LastUsedAt,
+		AccessTokenID:        r.AccessTokenID,
+		AccessTokenHash:      r.AccessTokenHash,
+		AccessTokenExpiresAt: r.AccessTokenExpiresAt,
+		RefreshToken:         refreshToken,
+	}, nil
+}
+
+// Save создаёт или обновляет сессию. При ротации refresh-токена старый индекс удаляется.
+// Если передан открытый AccessToken, из него берутся jti, хеш и срок действия.
+func (s *SessionStore) Save(ctx context.Context, session auth.Session) error {
+	ttl := s.ttl(session.RefreshToken)
+	if ttl <= 0 {
+		return nil
+	}
+
+	previous, err := s.get(ctx, session.Id)
+	if err != nil {
+		return err
+	}
+
+	if session.AccessToken != "" {
+		session.AccessTokenHash = jwt.TokenHash(session.AccessToken)
+		session.AccessTokenID, session.AccessTokenExpiresAt = "", time.Time{}
+		if claims, err := jwt.ParseUnverified(session.AccessToken); err == nil {
+			session.AccessTokenID = claims.ID
+			session.AccessTokenExpiresAt, _ = claims.Expiry()
+		}
+	}
+
+	sealed, err := s.seal(session.Id, session.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record{
+		Id:                   session.Id,
+		UserId:               session.UserId,
+		UserAgent:            session.UserAgent,
+		IP:                   session.IP,
+		CreatedAt:            session.CreatedAt,
+		LastUsedAt:           session.LastUsedAt,
+		AccessTokenID:        session.AccessTokenID,
+		AccessTokenHash:      session.AccessTokenHash,
+		AccessTokenExpiresAt: session.AccessTokenExpiresAt,
+		RefreshTokenSealed:   sealed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	if previous != nil && previous.RefreshToken != session.RefreshToken {
+		pipe.Del(ctx, refreshKeyPrefix+jwt.TokenHash(previous.RefreshToken))
+	}
+	pipe.Set(ctx, sessionKeyPrefix+session.Id, data, ttl)
+	pipe.Set(ctx, refreshKeyPrefix+jwt.TokenHash(session.RefreshToken), session.Id, ttl)
+	// Индекс живёт до истечения самой долгой сессии: NX задаёт срок новому ключу, GT только продлевает
+	// (Redis 7+). Иначе короткая сессия укоротила бы индекс, и более долгие пропали бы из ListByUser.
+	pipe.SAdd(ctx, userKeyPrefix+session.UserId, session.Id)
+	pipe.ExpireNX(ctx, userKeyPrefix+session.UserId, ttl)
+	pipe.ExpireGT(ctx, userKeyPrefix+session.UserId, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionStore) Get(ctx context.Context, userId, id string) (*auth.Session, error) {
+	session, err := s.get(ctx, id)
+	if err != nil || session == nil {
+		return nil, err
+	}
+
+	// Чужая сессия неотличима от несуществующей
+	if session.UserId != userId {
+		return nil, nil
+	}
+
+	return session, nil
+}
+
+func (s *SessionStore) GetByRefreshToken(ctx context.Context, refreshToken string) (*auth.Session, error) {
+	id, err := s.client.Get(ctx, refreshKeyPrefix+jwt.TokenHash(refreshToken)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	return s.get(ctx, id)
+}
+
+func (s *SessionStore) ListByUser(ctx context.Context, userId string) ([]auth.Session, error) {
+	ids, err := s.client.SMembers(ctx, userKeyPrefix+userId).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return []auth.Session{}, nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, sessionKeyPrefix+id)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	sessions := make([]auth.Session, 0, len(values))
+	expired := make([]any, 0)
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+
+		session, err := s.decode([]byte(str))
+		if errors.Is(err, errUnreadableSession) {
+			_ = s.client.Del(ctx, keys[i]).Err()
+			expired = append(expired, ids[i])
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+
+	// Истёкшие по TTL и нечитаемые сессии остаются в индексе пользователя — чистим их
+	if len(expired) > 0 {
+		_ = s.client.SRem(ctx, userKeyPrefix+userId, expired...).Err()
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionStore) Delete(ctx context.Context, session auth.Session) error {
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, sessionKeyPrefix+session.Id)
+	pipe.Del(ctx, refreshKeyPrefix+jwt.TokenHash(session.RefreshToken))
+	pipe.SRem(ctx, userKeyPrefix+session.UserId, session.Id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
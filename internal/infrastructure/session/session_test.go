@@ -0,0 +1,259 @@
+package session
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testKey = []byte(strings.Repeat("k", 32))
+
+func newTestStore(t *testing.T, key []byte) (*SessionStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	return newTestStoreOn(t, mr, key), mr
+}
+
+func newTestStoreOn(t *testing.T, mr *miniredis.Miniredis, key []byte) *SessionStore {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store, err := NewSessionStore(client, time.Hour, key)
+	if err != nil {
+		t.Fatalf("NewSessionStore: %v", err)
+	}
+
+	return store
+}
+
+// testToken — JWT без подписи; хранилищу нужны только jti и exp
+func testToken(t *testing.T, jti string, ttl time.Duration) string {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]any{"jti": jti, "exp": time.Now().Add(ttl).Unix()})
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"RS256"}`)) + "." + enc.EncodeToString(payload) + ".c2ln"
+}
+
+func testSession(t *testing.T, id string, ttl time.Duration) auth.Session {
+	t.Helper()
+
+	return auth.Session{
+		Id:           id,
+		UserId:       "u1",
+		UserAgent:    "test",
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		LastUsedAt:   time.Now().UTC().Truncate(time.Second),
+		AccessToken:  testToken(t, "access-"+id, 15*time.Minute),
+		RefreshToken: testToken(t, "refresh-"+id, ttl),
+	}
+}
+
+func TestSaveKeepsNoPlainTokens(t *testing.T) {
+	store, mr := newTestStore(t, testKey)
+	ctx := context.Background()
+	session := testSession(t, "s1", time.Hour)
+
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	raw, err := mr.Get(sessionKeyPrefix + "s1")
+	if err != nil {
+		t.Fatalf("read raw session: %v", err)
+	}
+	for _, token := range []string{session.AccessToken, session.RefreshToken} {
+		if strings.Contains(raw, token) {
+			t.Errorf("raw session contains a plain token: %s", raw)
+		}
+	}
+
+	got, err := store.GetByRefreshToken(ctx, session.RefreshToken)
+	if err != nil || got == nil {
+		t.Fatalf("GetByRefreshToken() = %v, %v", got, err)
+	}
+	if got.RefreshToken != session.RefreshToken {
+		t.Errorf("RefreshToken was not restored")
+	}
+	if got.AccessToken != "" {
+		t.Errorf("AccessToken = %q, want it not to be stored", got.AccessToken)
+	}
+	if got.AccessTokenID != "access-s1" || got.AccessTokenHash != jwt.TokenHash(session.AccessToken) ||
+		got.AccessTokenExpiresAt.IsZero() {
+		t.Errorf("access token metadata = %q/%q/%v", got.AccessTokenID, got.AccessTokenHash, got.AccessTokenExpiresAt)
+	}
+}
+
+func TestUnreadableSessions(t *testing.T) {
+	tests := []struct {
+		name string
+		// tamper портит сохранённую запись s1 и возвращает хранилище для чтения
+		tamper func(t *testing.T, mr *miniredis.Miniredis) *SessionStore
+	}{
+		{
+			name: "other encryption key",
+			tamper: func(t *testing.T, mr *miniredis.Miniredis) *SessionStore {
+				return newTestStoreOn(t, mr, []byte(strings.Repeat("o", 32)))
+			},
+		},
+		{
+			name: "sealed token moved to another session",
+			tamper: func(t *testing.T, mr *miniredis.Miniredis) *SessionStore {
+				raw1, _ := mr.Get(sessionKeyPrefix + "s1")
+				raw2, _ := mr.Get(sessionKeyPrefix + "s2")
+
+				var r1, r2 record
+				_ = json.Unmarshal([]byte(raw1), &r1)
+				_ = json.Unmarshal([]byte(raw2), &r2)
+				r1.RefreshTokenSealed = r2.RefreshTokenSealed
+
+				data, _ := json.Marshal(r1)
+				_ = mr.Set(sessionKeyPrefix+"s1", string(data))
+				return newTestStoreOn(t, mr, testKey)
+			},
+		},
+		{
+			name: "old format with plain tokens",
+			tamper: func(t *testing.T, mr *miniredis.Miniredis) *SessionStore {
+				_ = mr.Set(sessionKeyPrefix+"s1", `{"id":"s1","user_id":"u1","refresh_token":"plain"}`)
+				return newTestStoreOn(t, mr, testKey)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mr := newTestStore(t, testKey)
+			ctx := context.Background()
+
+			s1 := testSession(t, "s1", time.Hour)
+			for _, s := range []auth.Session{s1, testSession(t, "s2", time.Hour)} {
+				if err := store.Save(ctx, s); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+
+			store = tt.tamper(t, mr)
+
+			got, err := store.GetByRefreshToken(ctx, s1.RefreshToken)
+			if err != nil || got != nil {
+				t.Fatalf("GetByRefreshToken() = %+v, %v, want nil, nil", got, err)
+			}
+			if mr.Exists(sessionKeyPrefix + "s1") {
+				t.Error("unreadable session was not deleted")
+			}
+		})
+	}
+}
+
+func TestUserIndexTTL(t *testing.T) {
+	store, mr := newTestStore(t, testKey)
+	ctx := context.Background()
+
+	long := testSession(t, "long", 48*time.Hour)
+	short := testSession(t, "short", time.Hour)
+
+	for _, s := range []auth.Session{long, short} {
+		if err := store.Save(ctx, s); err != nil {
+			t.Fatalf("Save(%s): %v", s.Id, err)
+		}
+	}
+
+	// Короткая сессия, сохранённая последней, не укорачивает индекс
+	if ttl := mr.TTL(userKeyPrefix + "u1"); ttl < 47*time.Hour {
+		t.Fatalf("user index ttl = %v, want about 48h", ttl)
+	}
+
+	mr.FastForward(2 * time.Hour)
+
+	sessions, err := store.ListByUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("ListByUser: %v", err)
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		ids = append(ids, s.Id)
+	}
+	if !slices.Equal(ids, []string{"long"}) {
+		t.Errorf("sessions = %v, want [long]", ids)
+	}
+
+	// Истёкшая сессия вычищена из индекса
+	members, _ := mr.Members(userKeyPrefix + "u1")
+	if !slices.Equal(members, []string{"long"}) {
+		t.Errorf("index members = %v, want [long]", members)
+	}
+}
+
+func TestRefreshRotation(t *testing.T) {
+	store, mr := newTestStore(t, testKey)
+	ctx := context.Background()
+
+	session := testSession(t, "s1", time.Hour)
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	oldRefresh := session.RefreshToken
+	session.RefreshToken = testToken(t, "refresh-rotated", time.Hour)
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("Save rotated: %v", err)
+	}
+
+	if got, err := store.GetByRefreshToken(ctx, oldRefresh); err != nil || got != nil {
+		t.Errorf("old refresh token still finds session: %+v, %v", got, err)
+	}
+	if got, err := store.GetByRefreshToken(ctx, session.RefreshToken); err != nil || got == nil {
+		t.Errorf("rotated refresh token does not find session: %v", err)
+	}
+
+	if err := store.Delete(ctx, session); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("keys after Delete = %v, want none", keys)
+	}
+}
+
+func TestGetOtherUser(t *testing.T) {
+	store, _ := newTestStore(t, testKey)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, testSession(t, "s1", time.Hour)); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	tests := []struct {
+		userId string
+		want   bool
+	}{
+		{userId: "u1", want: true},
+		{userId: "u2", want: false},
+	}
+
+	for _, tt := range tests {
+		got, err := store.Get(ctx, tt.userId, "s1")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if (got != nil) != tt.want {
+			t.Errorf("Get(%s) found = %v, want %v", tt.userId, got != nil, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package stepup
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const keyPrefix = "auth:stepup:"
+
+var _ auth.StepUpStoreContract = (*StepUpStore)(nil)
+
+type StepUpStore struct {
+	client *redis.Client
+}
+
+func NewStepUpStore(client *redis.Client) *StepUpStore {
+	return &StepUpStore{client: client}
+}
+
+// grant — владелец step-up токена; access-токен хранится только хешем
+type grant struct {
+	UserId          string `json:"user_id"`
+	AccessTokenHash string `json:"access_token_hash"`
+}
+
+// В Redis хранится только хеш токена
+func key(token string) string {
+	return keyPrefix + jwt.TokenHash(token)
+}
+
+func (s *StepUpStore) Issue(ctx context.Context, userId, accessToken string, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate step-up token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	data, err := json.Marshal(grant{UserId: userId, AccessTokenHash: jwt.TokenHash(accessToken)})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode step-up token: %w", err)
+	}
+
+	if err := s.client.Set(ctx, key(token), data, ttl).Err(); err != nil {
+		return "", fmt.Errorf("failed to save step-up token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *StepUpStore) Match(ctx context.Context, token, userId, accessToken string) (bool, error) {
+	data, err := s.client.Get(ctx, key(token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get step-up token: %w", err)
+	}
+
+	var g grant
+	if err := json.Unmarshal(data, &g); err != nil {
+		return false, fmt.Errorf("failed to decode step-up token: %w", err)
+	}
+
+	sameSession := subtle.ConstantTimeCompare([]byte(g.AccessTokenHash), []byte(jwt.TokenHash(accessToken))) == 1
+
+	return g.UserId == userId && sameSession, nil
+}
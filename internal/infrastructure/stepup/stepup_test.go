@@ -0,0 +1,106 @@
+package stepup
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) (*StepUpStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return NewStepUpStore(client), mr
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		userId      string
+		accessToken string
+		want        bool
+	}{
+		{name: "same user and session", userId: "1", accessToken: "access-1", want: true},
+		{name: "other session of the same user", userId: "1", accessToken: "access-2"},
+		{name: "other user", userId: "2", accessToken: "access-1"},
+		{name: "no access token", userId: "1"},
+		{name: "unknown token", token: "forged", userId: "1", accessToken: "access-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _ := newTestStore(t)
+			ctx := context.Background()
+
+			token, err := store.Issue(ctx, "1", "access-1", time.Minute)
+			if err != nil {
+				t.Fatalf("Issue: %v", err)
+			}
+			if tt.token != "" {
+				token = tt.token
+			}
+
+			got, err := store.Match(ctx, token, tt.userId, tt.accessToken)
+			if err != nil {
+				t.Fatalf("Match: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssue(t *testing.T) {
+	store, mr := newTestStore(t)
+	ctx := context.Background()
+
+	token, err := store.Issue(ctx, "1", "access-1", time.Minute)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	keys := mr.Keys()
+	if len(keys) != 1 || keys[0] != key(token) {
+		t.Fatalf("keys = %v, want [%s]", keys, key(token))
+	}
+
+	// Ни step-up, ни access-токен не хранятся в открытом виде
+	raw, _ := mr.Get(keys[0])
+	if strings.Contains(keys[0], token) || strings.Contains(raw, token) || strings.Contains(raw, "access-1") {
+		t.Errorf("plain token stored: %s = %s", keys[0], raw)
+	}
+
+	if ttl := mr.TTL(keys[0]); ttl != time.Minute {
+		t.Errorf("ttl = %v, want %v", ttl, time.Minute)
+	}
+
+	mr.FastForward(time.Minute)
+
+	if ok, err := store.Match(ctx, token, "1", "access-1"); err != nil || ok {
+		t.Errorf("expired token Match() = %v, %v, want false", ok, err)
+	}
+}
+
+func TestMatchRedisDown(t *testing.T) {
+	store, mr := newTestStore(t)
+	ctx := context.Background()
+
+	token, err := store.Issue(ctx, "1", "access-1", time.Minute)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+
+	mr.Close()
+
+	if ok, err := store.Match(ctx, token, "1", "access-1"); err == nil || ok {
+		t.Errorf("Match() = %v, %v, want an error", ok, err)
+	}
+}
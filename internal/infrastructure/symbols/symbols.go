@@ -0,0 +1,116 @@
+package symbols
+
+import (
+	"cmp"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//go:embed symbols.json
+var symbolsData []byte
+
+var _ portfolio.SymbolRegistryContract = (*Registry)(nil)
+
+type symbolEntry struct {
+	Ticker    string   `json:"ticker"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	Precision int      `json:"precision"`
+}
+
+// Registry — реестр активов из встроенного symbols.json. Неизменяем после создания.
+type Registry struct {
+	// Тикеры и псевдонимы в верхнем регистре
+	byKey   map[string]portfolio.Symbol
+	symbols []portfolio.Symbol
+}
+
+func NewRegistry() (*Registry, error) {
+	var entries []symbolEntry
+	if err := json.Unmarshal(symbolsData, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse symbols: %w", err)
+	}
+
+	r := &Registry{
+		byKey:   make(map[string]portfolio.Symbol, len(entries)),
+		symbols: make([]portfolio.Symbol, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		symbol := portfolio.Symbol{
+			Ticker:    strings.ToUpper(e.Ticker),
+			Name:      e.Name,
+			Aliases:   make([]string, 0, len(e.Aliases)),
+			Precision: e.Precision,
+		}
+		if symbol.Ticker == "" || symbol.Precision < 0 {
+			return nil, fmt.Errorf("invalid symbol entry %q", e.Ticker)
+		}
+
+		for _, alias := range e.Aliases {
+			symbol.Aliases = append(symbol.Aliases, strings.ToUpper(alias))
+		}
+
+		for _, key := range append([]string{symbol.Ticker}, symbol.Aliases...) {
+			if _, ok := r.byKey[key]; ok {
+				return nil, fmt.Errorf("duplicate symbol %q", key)
+			}
+			r.byKey[key] = symbol
+		}
+
+		r.symbols = append(r.symbols, symbol)
+	}
+
+	slices.SortFunc(r.symbols, func(a, b portfolio.Symbol) int {
+		return cmp.Compare(a.Ticker, b.Ticker)
+	})
+
+	return r, nil
+}
+
+func (r *Registry) Resolve(symbol string) (portfolio.Symbol, bool) {
+	s, ok := r.byKey[strings.ToUpper(strings.TrimSpace(symbol))]
+	return s, ok
+}
+
+func (r *Registry) Search(prefix string, limit int) []portfolio.Symbol {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+
+	var exact *portfolio.Symbol
+	if s, ok := r.byKey[prefix]; ok && prefix != "" {
+		exact = &s
+	}
+
+	res := make([]portfolio.Symbol, 0, min(limit, len(r.symbols)))
+	if exact != nil {
+		res = append(res, *exact)
+	}
+
+	for _, s := range r.symbols {
+		if len(res) >= limit {
+			break
+		}
+		if exact != nil && s.Ticker == exact.Ticker {
+			continue
+		}
+		if matchesPrefix(s, prefix) {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+func matchesPrefix(s portfolio.Symbol, prefix string) bool {
+	if strings.HasPrefix(s.Ticker, prefix) || strings.HasPrefix(strings.ToUpper(s.Name), prefix) {
+		return true
+	}
+
+	return slices.ContainsFunc(s.Aliases, func(alias string) bool {
+		return strings.HasPrefix(alias, prefix)
+	})
+}
@@ -0,0 +1,230 @@
+package tlscreds
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Files — пути к PEM-файлам. CAFile пуст — используются системные корни,
+// CertFile и KeyFile пусты — клиентский сертификат не предъявляется (TLS без mTLS).
+type Files struct {
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// Reloader держит актуальные сертификаты и перечитывает файлы при их изменении,
+// поэтому ротация сертификатов не требует перезапуска gateway.
+type Reloader struct {
+	files Files
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	roots    *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// NewReloader загружает сертификаты сразу и возвращает понятную ошибку, если они невалидны.
+func NewReloader(files Files) (*Reloader, error) {
+	if (files.CertFile == "") != (files.KeyFile == "") {
+		return nil, errors.New("client certificate and key must be set together")
+	}
+
+	r := &Reloader{files: files}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Reloader) paths() []string {
+	paths := make([]string, 0, 3)
+	for _, p := range []string{r.files.CAFile, r.files.CertFile, r.files.KeyFile} {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+
+	return paths
+}
+
+func (r *Reloader) reload() error {
+	modTimes := make(map[string]time.Time)
+	for _, p := range r.paths() {
+		info, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		modTimes[p] = info.ModTime()
+	}
+
+	var roots *x509.CertPool
+	if r.files.CAFile != "" {
+		pem, err := os.ReadFile(r.files.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA bundle %s: %w", r.files.CAFile, err)
+		}
+
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("CA bundle %s contains no valid PEM certificates", r.files.CAFile)
+		}
+	}
+
+	var cert *tls.Certificate
+	if r.files.CertFile != "" {
+		pair, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate %s / %s: %w", r.files.CertFile, r.files.KeyFile, err)
+		}
+
+		leaf, err := x509.ParseCertificate(pair.Certificate[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse client certificate %s: %w", r.files.CertFile, err)
+		}
+		if time.Now().After(leaf.NotAfter) {
+			return fmt.Errorf("client certificate %s expired at %s", r.files.CertFile, leaf.NotAfter.Format(time.RFC3339))
+		}
+		pair.Leaf = leaf
+
+		cert = &pair
+	}
+
+	r.mu.Lock()
+	r.cert = cert
+	r.roots = roots
+	r.modTimes = modTimes
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, p := range r.paths() {
+		info, err := os.Stat(p)
+		if err != nil {
+			// Файл может временно отсутствовать во время замены — проверим на следующем тике
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[p]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Start проверяет файлы каждые interval до отмены ctx.
+// Невалидные новые файлы не применяются: продолжают работать предыдущие сертификаты.
+func (r *Reloader) Start(ctx context.Context, interval time.Duration) {
+	log := logger.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !r.changed() {
+					continue
+				}
+
+				if err := r.reload(); err != nil {
+					log.Error("failed to reload tls credentials, keeping previous ones", zap.Error(err))
+					continue
+				}
+
+				log.Info("tls credentials reloaded")
+			}
+		}
+	}()
+}
+
+// TransportCredentials возвращает gRPC credentials, которые берут текущие сертификаты при каждом рукопожатии.
+// serverName — имя (DNS или IP), с которым сверяется сертификат сервера.
+func (r *Reloader) TransportCredentials(serverName string) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			if r.cert == nil {
+				// Пустой сертификат: сервер без требования mTLS примет соединение
+				return &tls.Certificate{}, nil
+			}
+
+			return r.cert, nil
+		},
+		// Стандартная проверка отключена только для того, чтобы выполнять её с актуальным CA bundle
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return r.verifyConnection(cs, serverName)
+		},
+	})
+}
+
+// ServerNameFromAddr возвращает хост из адреса вида [scheme:///]host:port для проверки сертификата.
+func ServerNameFromAddr(addr string) string {
+	if i := strings.LastIndex(addr, "/"); i >= 0 {
+		addr = addr[i+1:]
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
+
+// verifyConnection сверяет сертификат с serverName, а не с cs.ServerName:
+// для IP-адресов SNI не отправляется и cs.ServerName пуст.
+func (r *Reloader) verifyConnection(cs tls.ConnectionState, serverName string) error {
+	if serverName == "" {
+		return errors.New("server name for certificate verification is not set")
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+
+	r.mu.RLock()
+	roots := r.roots
+	r.mu.RUnlock()
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		DNSName:       serverName,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify server certificate: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package tokencache
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const keyPrefix = "auth:verify:"
+
+var _ auth.VerifyCacheContract = (*TokenCache)(nil)
+
+type TokenCache struct {
+	client *redis.Client
+}
+
+func NewTokenCache(client *redis.Client) *TokenCache {
+	return &TokenCache{client: client}
+}
+
+func (c *TokenCache) key(token string) string {
+	return keyPrefix + jwt.TokenHash(token)
+}
+
+func (c *TokenCache) Get(ctx context.Context, token string) (*portfolio.User, error) {
+	data, err := c.client.Get(ctx, c.key(token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached user: %w", err)
+	}
+
+	var user portfolio.User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("failed to decode cached user: %w", err)
+	}
+
+	return &user, nil
+}
+
+func (c *TokenCache) Set(ctx context.Context, token string, user *portfolio.User, ttl time.Duration) error {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to encode user: %w", err)
+	}
+
+	if err := c.client.Set(ctx, c.key(token), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache user: %w", err)
+	}
+
+	return nil
+}
+
+func (c *TokenCache) Delete(ctx context.Context, token string) error {
+	if err := c.client.Del(ctx, c.key(token)).Err(); err != nil {
+		return fmt.Errorf("failed to evict cached user: %w", err)
+	}
+
+	return nil
+}
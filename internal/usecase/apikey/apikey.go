@@ -0,0 +1,141 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto_analyzer-api_gateway/internal/domain/apikey"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	secretPrefix   = "cak_"
+	maxKeysPerUser = 20
+)
+
+type APIKeyUsecase struct {
+	store apikey.APIKeyStoreContract
+}
+
+func NewAPIKeyUsecase(store apikey.APIKeyStoreContract) *APIKeyUsecase {
+	return &APIKeyUsecase{store: store}
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return encode(b), nil
+}
+
+// Create выпускает ключ и возвращает его вместе с секретом. Секрет показывается только один раз.
+func (u APIKeyUsecase) Create(ctx context.Context, user *portfolio.User, name string, portfolioIds []int32,
+	access string) (apikey.APIKey, string, error) {
+	if name == "" {
+		return apikey.APIKey{}, "", apikey.ErrNameRequired
+	}
+
+	if len(portfolioIds) == 0 {
+		return apikey.APIKey{}, "", apikey.ErrPortfolioIdsRequired
+	}
+	for _, id := range portfolioIds {
+		if id <= 0 {
+			return apikey.APIKey{}, "", apikey.ErrInvalidPortfolioId
+		}
+	}
+
+	switch access {
+	case apikey.AccessRead:
+		if !user.HasScope(portfolio.ScopePortfolioRead) {
+			return apikey.APIKey{}, "", apikey.ErrScopeNotGranted
+		}
+	case apikey.AccessReadWrite:
+		if !user.HasScope(portfolio.ScopePortfolioRead) || !user.HasScope(portfolio.ScopePortfolioWrite) {
+			return apikey.APIKey{}, "", apikey.ErrScopeNotGranted
+		}
+	default:
+		return apikey.APIKey{}, "", apikey.ErrInvalidAccess
+	}
+
+	existing, err := u.store.ListByUser(ctx, user.Id)
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+	if len(existing) >= maxKeysPerUser {
+		return apikey.APIKey{}, "", apikey.ErrTooManyKeys
+	}
+
+	id, err := randomString(8, hex.EncodeToString)
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	secret, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+	secret = secretPrefix + secret
+
+	key := apikey.APIKey{
+		Id:           id,
+		UserId:       user.Id,
+		Username:     user.Username,
+		Email:        user.Email,
+		Name:         name,
+		PortfolioIds: slices.Compact(slices.Sorted(slices.Values(portfolioIds))),
+		Access:       access,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	if err := u.store.Create(ctx, key, secret); err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	return key, secret, nil
+}
+
+func (u APIKeyUsecase) List(ctx context.Context, userId string) ([]apikey.APIKey, error) {
+	keys, err := u.store.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(keys, func(a, b apikey.APIKey) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return keys, nil
+}
+
+func (u APIKeyUsecase) Revoke(ctx context.Context, userId, id string) (bool, error) {
+	return u.store.Delete(ctx, userId, id)
+}
+
+// Resolve находит ключ по секрету и возвращает пользователя с урезанными правами ключа.
+// Возвращает nil, nil, если ключ не найден. Ключ без портфелей не принимается:
+// у пользователя с пустым AllowedPortfolioIds доступ не ограничен.
+func (u APIKeyUsecase) Resolve(ctx context.Context, secret string) (*portfolio.User, error) {
+	key, err := u.store.GetBySecret(ctx, secret)
+	if err != nil || key == nil || len(key.PortfolioIds) == 0 {
+		return nil, err
+	}
+
+	scopes := []string{portfolio.ScopePortfolioRead}
+	if key.Access == apikey.AccessReadWrite {
+		scopes = append(scopes, portfolio.ScopePortfolioWrite)
+	}
+
+	return &portfolio.User{
+		Id:                  key.UserId,
+		Username:            key.Username,
+		Email:               key.Email,
+		Scopes:              scopes,
+		AllowedPortfolioIds: key.PortfolioIds,
+	}, nil
+}
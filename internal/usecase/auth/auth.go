@@ -1,11 +1,319 @@
 package auth
 
-import domain "crypto_analyzer-api_gateway/internal/domain/auth"
+import (
+	"context"
+	"crypto/rand"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+	"time"
+)
 
 type AuthServiceUsecase struct {
 	authService domain.AuthServiceContract
+	verifyCache domain.VerifyCacheContract
+	revocations domain.RevocationListContract
+	sessions    domain.SessionStoreContract
+	loginGuard  domain.LoginGuardContract
 }
 
-func NewAuthServiceUsecase(authService domain.AuthServiceContract) *AuthServiceUsecase {
-	return &AuthServiceUsecase{authService: authService}
+func NewAuthServiceUsecase(authService domain.AuthServiceContract, verifyCache domain.VerifyCacheContract,
+	revocations domain.RevocationListContract, sessions domain.SessionStoreContract,
+	loginGuard domain.LoginGuardContract) *AuthServiceUsecase {
+	return &AuthServiceUsecase{
+		authService: authService,
+		verifyCache: verifyCache,
+		revocations: revocations,
+		sessions:    sessions,
+		loginGuard:  loginGuard,
+	}
+}
+
+func (u AuthServiceUsecase) Register(ctx context.Context, username, email, password string,
+	client domain.ClientInfo) (domain.Tokens, error) {
+	tokens, err := u.authService.Register(ctx, username, email, password)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	u.startSession(ctx, tokens, client)
+
+	return tokens, nil
+}
+
+// Login проверяет блокировку по аккаунту и IP до обращения в Auth Service.
+// После серии неверных паролей возвращает *domain.LockedError.
+func (u AuthServiceUsecase) Login(ctx context.Context, email, username, password string,
+	client domain.ClientInfo) (domain.Tokens, error) {
+	log := logger.FromContext(ctx)
+	account := u.loginAccount(ctx, email, username)
+
+	retryAfter, err := u.loginGuard.Check(ctx, account, client.IP)
+	if err != nil {
+		// Недоступность Redis не должна ломать вход
+		log.Warn("failed to check login lock", zap.Error(err))
+	} else if retryAfter > 0 {
+		return domain.Tokens{}, &domain.LockedError{RetryAfter: retryAfter}
+	}
+
+	tokens, err := u.authService.Login(ctx, email, username, password)
+	if err != nil {
+		if !isCredentialError(err) {
+			return domain.Tokens{}, err
+		}
+
+		lockout, guardErr := u.loginGuard.RegisterFailure(ctx, account, client.IP)
+		if guardErr != nil {
+			log.Warn("failed to register login failure", zap.Error(guardErr))
+			return domain.Tokens{}, err
+		}
+
+		if lockout > 0 {
+			log.Warn("login locked", zap.String("ip", client.IP), zap.Duration("lockout", lockout))
+			return domain.Tokens{}, &domain.LockedError{RetryAfter: lockout}
+		}
+
+		return domain.Tokens{}, err
+	}
+
+	if err := u.loginGuard.Reset(ctx, account); err != nil {
+		log.Warn("failed to reset login failures", zap.Error(err))
+	}
+
+	u.startSession(ctx, tokens, client)
+
+	return tokens, nil
+}
+
+func (u AuthServiceUsecase) Refresh(ctx context.Context, refreshToken string, client domain.ClientInfo) (domain.Tokens, error) {
+	log := logger.FromContext(ctx)
+
+	tokens, err := u.authService.Refresh(ctx, refreshToken)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+
+	session, err := u.sessions.GetByRefreshToken(ctx, refreshToken)
+	if err != nil {
+		log.Warn("failed to find session", zap.Error(err))
+		return tokens, nil
+	}
+
+	// Сессия могла быть создана до появления учёта сессий — заводим новую
+	if session == nil {
+		u.startSession(ctx, tokens, client)
+		return tokens, nil
+	}
+
+	session.AccessToken = tokens.AccessToken
+	session.RefreshToken = tokens.RefreshToken
+	session.LastUsedAt = time.Now().UTC()
+	session.UserAgent = client.UserAgent
+	session.IP = client.IP
+
+	if err := u.sessions.Save(ctx, *session); err != nil {
+		log.Warn("failed to update session", zap.String("session_id", session.Id), zap.Error(err))
+	}
+
+	return tokens, nil
+}
+
+// Logout завершает сессию в Auth Service и отзывает access-токен, записанный в этой сессии.
+// accessToken клиента не проверен (может быть пустым или поддельным), поэтому отзывается только по хешу:
+// так он снимает лишь сам себя и не может отозвать чужой токен, подставив его jti.
+func (u AuthServiceUsecase) Logout(ctx context.Context, refreshToken, accessToken string) error {
+	log := logger.FromContext(ctx)
+
+	if err := u.authService.Logout(ctx, refreshToken); err != nil {
+		return err
+	}
+
+	if session, err := u.sessions.GetByRefreshToken(ctx, refreshToken); err != nil {
+		log.Warn("failed to find session", zap.Error(err))
+	} else if session != nil {
+		if err := u.revokeSessionAccessToken(ctx, *session); err != nil {
+			return err
+		}
+		if err := u.sessions.Delete(ctx, *session); err != nil {
+			log.Warn("failed to delete session", zap.Error(err))
+		}
+	}
+
+	if accessToken == "" {
+		return nil
+	}
+
+	if err := u.revocations.RevokeTokenHash(ctx, jwt.TokenHash(accessToken), time.Time{}); err != nil {
+		return err
+	}
+
+	if err := u.verifyCache.Delete(ctx, accessToken); err != nil {
+		log.Warn("failed to evict verify cache", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (u AuthServiceUsecase) RevokeToken(ctx context.Context, accessToken string) error {
+	if err := u.revocations.RevokeToken(ctx, accessToken); err != nil {
+		return err
+	}
+
+	if err := u.verifyCache.Delete(ctx, accessToken); err != nil {
+		logger.FromContext(ctx).Warn("failed to evict verify cache", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (u AuthServiceUsecase) RevokeTokenID(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return u.revocations.RevokeTokenID(ctx, tokenID, expiresAt)
+}
+
+func (u AuthServiceUsecase) ListSessions(ctx context.Context, userId string) ([]domain.Session, error) {
+	sessions, err := u.sessions.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(sessions, func(a, b domain.Session) int {
+		return b.LastUsedAt.Compare(a.LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession возвращает false, если у пользователя нет такой сессии.
+func (u AuthServiceUsecase) RevokeSession(ctx context.Context, userId, id string) (bool, error) {
+	session, err := u.sessions.Get(ctx, userId, id)
+	if err != nil || session == nil {
+		return false, err
+	}
+
+	return true, u.revokeSession(ctx, *session)
+}
+
+// RevokeAllSessions завершает все сессии пользователя и возвращает количество завершённых.
+// Ошибка одной сессии не останавливает остальные; ошибки возвращаются вместе.
+func (u AuthServiceUsecase) RevokeAllSessions(ctx context.Context, userId string) (int, error) {
+	sessions, err := u.sessions.ListByUser(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	var errs []error
+	for _, session := range sessions {
+		if err := u.revokeSession(ctx, session); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		revoked++
+	}
+
+	return revoked, errors.Join(errs...)
+}
+
+func (u AuthServiceUsecase) revokeSession(ctx context.Context, session domain.Session) error {
+	if err := u.authService.Logout(ctx, session.RefreshToken); err != nil {
+		// Refresh-токен уже недействителен — сессию всё равно нужно закрыть
+		st, _ := status.FromError(err)
+		if st.Code() != codes.Unauthenticated && st.Code() != codes.NotFound {
+			return fmt.Errorf("failed to logout session %s: %w", session.Id, err)
+		}
+	}
+
+	if err := u.revokeSessionAccessToken(ctx, session); err != nil {
+		return err
+	}
+
+	return u.sessions.Delete(ctx, session)
+}
+
+// revokeSessionAccessToken отзывает access-токен сессии по jti или хешу: открытый токен не хранится.
+// Отзыв проверяется до кеша Verify, поэтому кеш чистить не нужно.
+func (u AuthServiceUsecase) revokeSessionAccessToken(ctx context.Context, session domain.Session) error {
+	if session.AccessTokenID != "" {
+		return u.revocations.RevokeTokenID(ctx, session.AccessTokenID, session.AccessTokenExpiresAt)
+	}
+	if session.AccessTokenHash != "" {
+		return u.revocations.RevokeTokenHash(ctx, session.AccessTokenHash, session.AccessTokenExpiresAt)
+	}
+
+	return nil
+}
+
+// loginAccount — ключ аккаунта для учёта неудачных входов. Вход по email и по username
+// считается одним счётчиком по id аккаунта, иначе чередование идентификаторов удваивало бы попытки.
+// Для несуществующего аккаунта или при недоступном Auth Service ключом остаётся сам идентификатор.
+func (u AuthServiceUsecase) loginAccount(ctx context.Context, email, username string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	username = strings.ToLower(strings.TrimSpace(username))
+
+	userId, err := u.authService.ResolveAccount(ctx, email, username)
+	if err == nil && userId != "" {
+		return "user:" + userId
+	}
+
+	if email != "" {
+		return "email:" + email
+	}
+
+	return "username:" + username
+}
+
+// isCredentialError — ошибка неверных учётных данных, а не сбой Auth Service
+func isCredentialError(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+
+	switch st.Code() {
+	case codes.Unauthenticated, codes.NotFound, codes.PermissionDenied:
+		return true
+	default:
+		return false
+	}
+}
+
+// startSession записывает сессию после выдачи токенов. Ошибки учёта не ломают вход.
+func (u AuthServiceUsecase) startSession(ctx context.Context, tokens domain.Tokens, client domain.ClientInfo) {
+	log := logger.FromContext(ctx)
+
+	user, err := u.authService.Verify(ctx, tokens.AccessToken)
+	if err != nil {
+		log.Warn("failed to resolve user for session", zap.Error(err))
+		return
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		log.Warn("failed to generate session id", zap.Error(err))
+		return
+	}
+
+	now := time.Now().UTC()
+	session := domain.Session{
+		Id:           hex.EncodeToString(id),
+		UserId:       user.Id,
+		UserAgent:    client.UserAgent,
+		IP:           client.IP,
+		CreatedAt:    now,
+		LastUsedAt:   now,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+
+	if err := u.sessions.Save(ctx, session); err != nil {
+		log.Warn("failed to save session", zap.String("user_id", user.Id), zap.Error(err))
+	}
 }
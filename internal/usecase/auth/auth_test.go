@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/jwt"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"testing"
+)
+
+func TestLogout(t *testing.T) {
+	// Поддельный неподписанный токен с jti чужого токена
+	forged := "eyJhbGciOiJub25lIn0.eyJqdGkiOiJ2aWN0aW0tanRpIiwic3ViIjoiMiJ9."
+	session := domain.Session{Id: "s1", UserId: "1", RefreshToken: "refresh-1", AccessTokenID: "own-jti"}
+	legacySession := domain.Session{Id: "s2", UserId: "1", RefreshToken: "refresh-2", AccessTokenHash: "own-hash"}
+
+	tests := []struct {
+		name         string
+		sessions     []domain.Session
+		refreshToken string
+		accessToken  string
+		logoutErr    error
+		revokeErr    error
+		wantErr      bool
+		wantRevoked  []string
+		wantSessions int
+	}{
+		{
+			name:         "revokes the session token and the presented token by hash",
+			sessions:     []domain.Session{session},
+			refreshToken: "refresh-1",
+			accessToken:  "access-1",
+			wantRevoked:  []string{"id own-jti", "hash " + jwt.TokenHash("access-1")},
+		},
+		{
+			name:         "forged token cannot revoke another jti",
+			sessions:     []domain.Session{session},
+			refreshToken: "refresh-1",
+			accessToken:  forged,
+			wantRevoked:  []string{"id own-jti", "hash " + jwt.TokenHash(forged)},
+		},
+		{
+			name:         "session without jti is revoked by stored hash",
+			sessions:     []domain.Session{legacySession},
+			refreshToken: "refresh-2",
+			wantRevoked:  []string{"hash own-hash"},
+		},
+		{
+			name:         "unknown session and no access token",
+			sessions:     []domain.Session{session},
+			refreshToken: "refresh-unknown",
+			wantSessions: 1,
+		},
+		{
+			name:         "auth service rejects refresh token",
+			sessions:     []domain.Session{session},
+			refreshToken: "refresh-1",
+			accessToken:  "access-1",
+			logoutErr:    status.Error(codes.Unauthenticated, "invalid refresh token"),
+			wantErr:      true,
+			wantSessions: 1,
+		},
+		{
+			name:         "revocation failure is returned",
+			sessions:     []domain.Session{session},
+			refreshToken: "refresh-1",
+			accessToken:  "access-1",
+			revokeErr:    errors.New("redis down"),
+			wantErr:      true,
+			wantSessions: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessions := newFakeSessions(tt.sessions...)
+			revocations := &fakeRevocations{err: tt.revokeErr}
+			authService := &fakeAuthService{logout: func(string) error { return tt.logoutErr }}
+			u := NewAuthServiceUsecase(authService, &fakeVerifyCache{}, revocations, sessions, nil)
+
+			err := u.Logout(context.Background(), tt.refreshToken, tt.accessToken)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(revocations.revoked, tt.wantRevoked) {
+				t.Errorf("revoked = %v, want %v", revocations.revoked, tt.wantRevoked)
+			}
+			if len(sessions.sessions) != tt.wantSessions {
+				t.Errorf("sessions left = %d, want %d", len(sessions.sessions), tt.wantSessions)
+			}
+		})
+	}
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	sessions := []domain.Session{
+		{Id: "s1", UserId: "1", RefreshToken: "refresh-1", AccessTokenID: "jti-1"},
+		{Id: "s2", UserId: "1", RefreshToken: "refresh-2", AccessTokenID: "jti-2"},
+		{Id: "s3", UserId: "1", RefreshToken: "refresh-3", AccessTokenID: "jti-3"},
+		{Id: "other", UserId: "2", RefreshToken: "refresh-other", AccessTokenID: "jti-other"},
+	}
+
+	tests := []struct {
+		name        string
+		logoutErrs  map[string]error
+		deleteErrs  map[string]error
+		wantRevoked int
+		wantErr     bool
+		wantLeft    []string
+	}{
+		{
+			name:        "all sessions",
+			wantRevoked: 3,
+			wantLeft:    []string{"other"},
+		},
+		{
+			name:        "already invalid refresh token is still closed",
+			logoutErrs:  map[string]error{"refresh-2": status.Error(codes.Unauthenticated, "expired")},
+			wantRevoked: 3,
+			wantLeft:    []string{"other"},
+		},
+		{
+			name:        "failure does not stop the remaining sessions",
+			logoutErrs:  map[string]error{"refresh-1": status.Error(codes.Unavailable, "auth down")},
+			deleteErrs:  map[string]error{"s2": errors.New("redis down")},
+			wantRevoked: 1,
+			wantErr:     true,
+			wantLeft:    []string{"other", "s1", "s2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeSessions(sessions...)
+			for id, err := range tt.deleteErrs {
+				store.failDelete[id] = err
+			}
+			authService := &fakeAuthService{logout: func(refreshToken string) error { return tt.logoutErrs[refreshToken] }}
+			u := NewAuthServiceUsecase(authService, &fakeVerifyCache{}, &fakeRevocations{}, store, nil)
+
+			revoked, err := u.RevokeAllSessions(context.Background(), "1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RevokeAllSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if revoked != tt.wantRevoked {
+				t.Errorf("revoked = %d, want %d", revoked, tt.wantRevoked)
+			}
+
+			left := make([]string, 0, len(store.sessions))
+			for id := range store.sessions {
+				left = append(left, id)
+			}
+			slices.Sort(left)
+			if !slices.Equal(left, tt.wantLeft) {
+				t.Errorf("sessions left = %v, want %v", left, tt.wantLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"sync"
+	"time"
+)
+
+// Фейки зависимостей usecase. Методы без заданной функции паникуют через встроенный nil-интерфейс.
+
+type fakeAuthService struct {
+	domain.AuthServiceContract
+
+	logout      func(refreshToken string) error
+	verifyTOTP  func(code string) (bool, error)
+	totpEnabled func() (bool, error)
+}
+
+func (f *fakeAuthService) Logout(_ context.Context, refreshToken string) error {
+	return f.logout(refreshToken)
+}
+
+func (f *fakeAuthService) VerifyTOTP(_ context.Context, code string) (bool, error) {
+	return f.verifyTOTP(code)
+}
+
+func (f *fakeAuthService) TOTPEnabled(context.Context) (bool, error) {
+	return f.totpEnabled()
+}
+
+// fakeRevocations записывает отзывы как "id <jti>" и "hash <sha256>"
+type fakeRevocations struct {
+	domain.RevocationListContract
+
+	mu      sync.Mutex
+	revoked []string
+	err     error
+}
+
+func (f *fakeRevocations) record(entry string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return f.err
+	}
+	f.revoked = append(f.revoked, entry)
+	return nil
+}
+
+func (f *fakeRevocations) RevokeTokenID(_ context.Context, tokenID string, _ time.Time) error {
+	return f.record("id " + tokenID)
+}
+
+func (f *fakeRevocations) RevokeTokenHash(_ context.Context, tokenHash string, _ time.Time) error {
+	return f.record("hash " + tokenHash)
+}
+
+type fakeVerifyCache struct {
+	domain.VerifyCacheContract
+}
+
+func (f *fakeVerifyCache) Delete(context.Context, string) error {
+	return nil
+}
+
+// fakeSessions хранит сессии по id
+type fakeSessions struct {
+	domain.SessionStoreContract
+
+	mu       sync.Mutex
+	sessions map[string]domain.Session
+	// failDelete — id сессий, удаление которых завершается ошибкой
+	failDelete map[string]error
+}
+
+func newFakeSessions(sessions ...domain.Session) *fakeSessions {
+	f := &fakeSessions{sessions: make(map[string]domain.Session), failDelete: make(map[string]error)}
+	for _, s := range sessions {
+		f.sessions[s.Id] = s
+	}
+
+	return f
+}
+
+func (f *fakeSessions) GetByRefreshToken(_ context.Context, refreshToken string) (*domain.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.sessions {
+		if s.RefreshToken == refreshToken {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeSessions) ListByUser(_ context.Context, userId string) ([]domain.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []domain.Session
+	for _, s := range f.sessions {
+		if s.UserId == userId {
+			res = append(res, s)
+		}
+	}
+
+	return res, nil
+}
+
+func (f *fakeSessions) Delete(_ context.Context, session domain.Session) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.failDelete[session.Id]; err != nil {
+		return err
+	}
+	delete(f.sessions, session.Id)
+	return nil
+}
+
+// fakeGuard блокирует после limit ошибок и записывает вызовы как "fail <account>" и "reset <account>"
+type fakeGuard struct {
+	limit    int
+	failures int
+	calls    []string
+}
+
+func (f *fakeGuard) Check(context.Context, string, string) (time.Duration, error) {
+	if f.limit > 0 && f.failures >= f.limit {
+		return time.Minute, nil
+	}
+	return 0, nil
+}
+
+func (f *fakeGuard) RegisterFailure(_ context.Context, account, _ string) (time.Duration, error) {
+	f.failures++
+	f.calls = append(f.calls, "fail "+account)
+	if f.limit > 0 && f.failures >= f.limit {
+		return time.Minute, nil
+	}
+	return 0, nil
+}
+
+func (f *fakeGuard) Reset(_ context.Context, account string) error {
+	f.failures = 0
+	f.calls = append(f.calls, "reset "+account)
+	return nil
+}
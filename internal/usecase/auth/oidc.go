@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// OIDCUsecase — вход через внешний OIDC-провайдер (authorization code + PKCE).
+// Токены gateway выдаёт Auth Service в обмен на подтверждённую внешнюю личность.
+type OIDCUsecase struct {
+	auth     *AuthServiceUsecase
+	provider domain.OIDCProviderContract
+	states   domain.OIDCStateStoreContract
+	stateTTL time.Duration
+}
+
+func NewOIDCUsecase(auth *AuthServiceUsecase, provider domain.OIDCProviderContract,
+	states domain.OIDCStateStoreContract, stateTTL time.Duration) *OIDCUsecase {
+	return &OIDCUsecase{
+		auth:     auth,
+		provider: provider,
+		states:   states,
+		stateTTL: stateTTL,
+	}
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashBinding(binding string) string {
+	sum := sha256.Sum256([]byte(binding))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// StateTTL — время жизни незавершённого входа; столько же живёт куки привязки к браузеру.
+func (u OIDCUsecase) StateTTL() time.Duration {
+	return u.stateTTL
+}
+
+// Begin создаёт state, nonce, code_verifier и значение привязки к браузеру.
+// Возвращает адрес страницы входа провайдера и значение привязки, которое кладётся в HttpOnly-куки.
+func (u OIDCUsecase) Begin(ctx context.Context, redirectAfter string) (string, string, error) {
+	var values [4]string
+	for i := range values {
+		v, err := randomString()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate oidc parameters: %w", err)
+		}
+		values[i] = v
+	}
+	state, nonce, codeVerifier, binding := values[0], values[1], values[2], values[3]
+
+	err := u.states.Save(ctx, state, domain.OIDCState{
+		Nonce:              nonce,
+		CodeVerifier:       codeVerifier,
+		RedirectAfter:      redirectAfter,
+		BrowserBindingHash: hashBinding(binding),
+	}, u.stateTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	return u.provider.AuthCodeURL(state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:])), binding, nil
+}
+
+// Complete завершает вход по callback провайдера и возвращает токены и сохранённый адрес возврата.
+// binding — значение куки браузера; state, начатый в другом браузере, отклоняется (login CSRF).
+func (u OIDCUsecase) Complete(ctx context.Context, state, binding, code string,
+	client domain.ClientInfo) (domain.Tokens, string, error) {
+	saved, err := u.states.Take(ctx, state)
+	if err != nil {
+		return domain.Tokens{}, "", err
+	}
+	if saved == nil {
+		return domain.Tokens{}, "", domain.ErrInvalidOIDCState
+	}
+
+	// state уже удалён: неудачная попытка сжигает его
+	if binding == "" || subtle.ConstantTimeCompare([]byte(hashBinding(binding)), []byte(saved.BrowserBindingHash)) != 1 {
+		return domain.Tokens{}, "", fmt.Errorf("%w: browser binding mismatch", domain.ErrInvalidOIDCState)
+	}
+
+	identity, err := u.provider.Exchange(ctx, code, saved.CodeVerifier, saved.Nonce)
+	if err != nil {
+		return domain.Tokens{}, "", err
+	}
+
+	tokens, err := u.auth.authService.ExchangeExternalIdentity(ctx, identity)
+	if err != nil {
+		return domain.Tokens{}, "", err
+	}
+
+	u.auth.startSession(ctx, tokens, client)
+
+	return tokens, saved.RedirectAfter, nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"fmt"
+	"go.uber.org/zap"
+	"time"
+)
+
+// StepUpUsecase — подтверждение чувствительных операций кодом TOTP.
+// Секрет TOTP хранится и проверяется в Auth Service, gateway выдаёт только step-up токены.
+type StepUpUsecase struct {
+	authService domain.AuthServiceContract
+	store       domain.StepUpStoreContract
+	// guard ограничивает подбор кодов так же, как подбор паролей
+	guard domain.LoginGuardContract
+	ttl   time.Duration
+}
+
+func NewStepUpUsecase(authService domain.AuthServiceContract, store domain.StepUpStoreContract,
+	guard domain.LoginGuardContract, ttl time.Duration) *StepUpUsecase {
+	return &StepUpUsecase{
+		authService: authService,
+		store:       store,
+		guard:       guard,
+		ttl:         ttl,
+	}
+}
+
+func (u StepUpUsecase) TTL() time.Duration {
+	return u.ttl
+}
+
+func (u StepUpUsecase) EnrollTOTP(ctx context.Context) (domain.TOTPEnrollment, error) {
+	return u.authService.EnrollTOTP(ctx)
+}
+
+func (u StepUpUsecase) ConfirmTOTP(ctx context.Context, code string) error {
+	return u.authService.ConfirmTOTP(ctx, code)
+}
+
+// verifyCode проверяет код TOTP с учётом блокировки после серии ошибок.
+// Без счётчика ошибок код не проверяется: иначе подбор шести цифр ничем не ограничен.
+func (u StepUpUsecase) verifyCode(ctx context.Context, userId, ip, code string) error {
+	log := logger.FromContext(ctx)
+	account := "otp:" + userId
+
+	retryAfter, err := u.guard.Check(ctx, account, ip)
+	if err != nil {
+		return fmt.Errorf("failed to check otp lock: %w", err)
+	}
+	if retryAfter > 0 {
+		return &domain.LockedError{RetryAfter: retryAfter}
+	}
+
+	valid, err := u.authService.VerifyTOTP(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		lockout, guardErr := u.guard.RegisterFailure(ctx, account, ip)
+		if guardErr != nil {
+			return fmt.Errorf("failed to register otp failure: %w", guardErr)
+		}
+		if lockout > 0 {
+			return &domain.LockedError{RetryAfter: lockout}
+		}
+
+		return domain.ErrInvalidStepUp
+	}
+
+	if err := u.guard.Reset(ctx, account); err != nil {
+		log.Warn("failed to reset otp failures", zap.Error(err))
+	}
+
+	return nil
+}
+
+// IssueToken обменивает код TOTP на step-up токен, который принимается на чувствительных маршрутах.
+// Токен привязан к access-токену, с которым он выдан, и не действует в других сессиях.
+func (u StepUpUsecase) IssueToken(ctx context.Context, userId, accessToken, ip, code string) (string, error) {
+	if err := u.verifyCode(ctx, userId, ip, code); err != nil {
+		return "", err
+	}
+
+	return u.store.Issue(ctx, userId, accessToken, u.ttl)
+}
+
+// CheckStepUp принимает step-up токен или код TOTP. Пользователям без TOTP подтверждение не требуется.
+// Возвращает domain.ErrStepUpRequired, если доказательство нужно, но не передано.
+func (u StepUpUsecase) CheckStepUp(ctx context.Context, userId, accessToken, ip, token, code string) error {
+	if token != "" {
+		if accessToken == "" {
+			return domain.ErrInvalidStepUp
+		}
+
+		ok, err := u.store.Match(ctx, token, userId, accessToken)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return domain.ErrInvalidStepUp
+		}
+
+		return nil
+	}
+
+	if code != "" {
+		return u.verifyCode(ctx, userId, ip, code)
+	}
+
+	enabled, err := u.authService.TOTPEnabled(ctx)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		return domain.ErrStepUpRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	domain "crypto_analyzer-api_gateway/internal/domain/auth"
+	"crypto_analyzer-api_gateway/internal/infrastructure/stepup"
+	"errors"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCheckStepUp(t *testing.T) {
+	const validCode = "123456"
+
+	tests := []struct {
+		name string
+		// useToken — передать токен, выданный пользователю "1" с access-токеном "access-1"
+		useToken    bool
+		token       string
+		userId      string
+		accessToken string
+		code        string
+		totpEnabled bool
+		guardLimit  int
+		guardFails  int
+		wantErr     error
+		wantLocked  bool
+		wantGuard   []string
+	}{
+		{name: "token from the same session", useToken: true, userId: "1", accessToken: "access-1"},
+		{name: "token from another session", useToken: true, userId: "1", accessToken: "access-2",
+			wantErr: domain.ErrInvalidStepUp},
+		{name: "token of another user", useToken: true, userId: "2", accessToken: "access-1",
+			wantErr: domain.ErrInvalidStepUp},
+		{name: "token without access token", useToken: true, userId: "1", wantErr: domain.ErrInvalidStepUp},
+		{name: "unknown token", token: "forged", userId: "1", accessToken: "access-1",
+			wantErr: domain.ErrInvalidStepUp},
+		{name: "invalid token is not replaced by totp check", token: "forged", userId: "1", accessToken: "access-1",
+			code: validCode, wantErr: domain.ErrInvalidStepUp},
+		{name: "valid code", userId: "1", accessToken: "access-1", code: validCode, totpEnabled: true,
+			wantGuard: []string{"reset otp:1"}},
+		{name: "invalid code", userId: "1", accessToken: "access-1", code: "000000", totpEnabled: true,
+			wantErr: domain.ErrInvalidStepUp, wantGuard: []string{"fail otp:1"}},
+		{name: "invalid code locks", userId: "1", accessToken: "access-1", code: "000000", totpEnabled: true,
+			guardLimit: 1, wantLocked: true, wantGuard: []string{"fail otp:1"}},
+		{name: "locked before checking code", userId: "1", accessToken: "access-1", code: validCode,
+			totpEnabled: true, guardLimit: 1, guardFails: 1, wantLocked: true},
+		{name: "no proof with totp", userId: "1", accessToken: "access-1", totpEnabled: true,
+			wantErr: domain.ErrStepUpRequired},
+		{name: "no proof without totp", userId: "1", accessToken: "access-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { _ = client.Close() })
+			store := stepup.NewStepUpStore(client)
+
+			authService := &fakeAuthService{
+				verifyTOTP:  func(code string) (bool, error) { return code == validCode, nil },
+				totpEnabled: func() (bool, error) { return tt.totpEnabled, nil },
+			}
+			guard := &fakeGuard{limit: tt.guardLimit, failures: tt.guardFails}
+			u := NewStepUpUsecase(authService, store, guard, time.Minute)
+			ctx := context.Background()
+
+			token := tt.token
+			if tt.useToken {
+				issued, err := store.Issue(ctx, "1", "access-1", time.Minute)
+				if err != nil {
+					t.Fatalf("Issue: %v", err)
+				}
+				token = issued
+			}
+
+			err := u.CheckStepUp(ctx, tt.userId, tt.accessToken, "10.0.0.1", token, tt.code)
+
+			var locked *domain.LockedError
+			switch {
+			case tt.wantLocked:
+				if !errors.As(err, &locked) {
+					t.Errorf("CheckStepUp() error = %v, want LockedError", err)
+				}
+			case !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil):
+				t.Errorf("CheckStepUp() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(guard.calls, tt.wantGuard) {
+				t.Errorf("guard calls = %v, want %v", guard.calls, tt.wantGuard)
+			}
+		})
+	}
+}
+
+func TestIssueToken(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	authService := &fakeAuthService{
+		verifyTOTP:  func(code string) (bool, error) { return code == "123456", nil },
+		totpEnabled: func() (bool, error) { return true, nil },
+	}
+	u := NewStepUpUsecase(authService, stepup.NewStepUpStore(client), &fakeGuard{}, time.Minute)
+	ctx := context.Background()
+
+	if _, err := u.IssueToken(ctx, "1", "access-1", "10.0.0.1", "000000"); !errors.Is(err, domain.ErrInvalidStepUp) {
+		t.Fatalf("IssueToken() with invalid code error = %v, want %v", err, domain.ErrInvalidStepUp)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Fatalf("token issued for invalid code: %v", keys)
+	}
+
+	token, err := u.IssueToken(ctx, "1", "access-1", "10.0.0.1", "123456")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	// Токен принимается только в сессии, в которой выдан
+	if err := u.CheckStepUp(ctx, "1", "access-1", "10.0.0.1", token, ""); err != nil {
+		t.Errorf("CheckStepUp() in the same session error = %v", err)
+	}
+	if err := u.CheckStepUp(ctx, "1", "access-2", "10.0.0.1", token, ""); !errors.Is(err, domain.ErrInvalidStepUp) {
+		t.Errorf("CheckStepUp() in another session error = %v, want %v", err, domain.ErrInvalidStepUp)
+	}
+
+	mr.FastForward(time.Minute)
+
+	if err := u.CheckStepUp(ctx, "1", "access-1", "10.0.0.1", token, ""); !errors.Is(err, domain.ErrInvalidStepUp) {
+		t.Errorf("CheckStepUp() with expired token error = %v, want %v", err, domain.ErrInvalidStepUp)
+	}
+}
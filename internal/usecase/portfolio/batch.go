@@ -0,0 +1,184 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// MaxAssetBatchSize — операций в одном пакете, включая пакеты импорта
+	MaxAssetBatchSize = 100
+
+	// Одновременных вызовов Portfolio Service на один пакет
+	assetBatchConcurrency = 8
+)
+
+// ApplyAssetBatch приводит символы к каноническим тикерам и выполняет операции пакета параллельно, не более assetBatchConcurrency одновременно.
+// В режиме allOrNothing после первой ошибки новые операции не запускаются, а уже применённые
+// отменяются компенсирующими вызовами по снимку портфеля, сделанному до начала пакета.
+func (u PortfolioUsecase) ApplyAssetBatch(ctx context.Context, portfolioId int, ops []portfolio.AssetOperation,
+	allOrNothing bool) (portfolio.AssetBatchResult, error) {
+	log := logger.FromContext(ctx)
+
+	if len(ops) > MaxAssetBatchSize {
+		return portfolio.AssetBatchResult{}, fmt.Errorf("%w: %d operations, at most %d",
+			portfolio.ErrAssetBatchTooLarge, len(ops), MaxAssetBatchSize)
+	}
+
+	ops = slices.Clone(ops)
+
+	var violations []portfolio.AssetViolation
+	for i := range ops {
+		if ops[i].Op == portfolio.AssetOpDelete {
+			ops[i].Symbol = u.normalizeDelete(ops[i].Symbol)
+			continue
+		}
+
+		var opViolations []portfolio.AssetViolation
+		ops[i].Symbol, opViolations = u.normalizeWrite(i, ops[i].Symbol, ops[i].Amount)
+		violations = append(violations, opViolations...)
+	}
+	if len(violations) > 0 {
+		return portfolio.AssetBatchResult{}, &portfolio.AssetValidationError{Violations: violations}
+	}
+
+	// Две операции над одним активом нельзя ни упорядочить, ни откатить однозначно
+	seen := make(map[string]struct{}, len(ops))
+	for _, op := range ops {
+		symbol := strings.ToUpper(op.Symbol)
+		if _, ok := seen[symbol]; ok {
+			return portfolio.AssetBatchResult{}, fmt.Errorf("%w: %s", portfolio.ErrDuplicateBatchSymbol, op.Symbol)
+		}
+		seen[symbol] = struct{}{}
+	}
+
+	var snapshot portfolio.PortfolioContent
+	if allOrNothing {
+		var err error
+		snapshot, err = u.portfolioService.GetPortfolioContentById(ctx, portfolioId)
+		if err != nil {
+			return portfolio.AssetBatchResult{}, err
+		}
+	}
+
+	res := portfolio.AssetBatchResult{Results: make([]portfolio.AssetOperationResult, len(ops))}
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Bool
+		sem    = make(chan struct{}, assetBatchConcurrency)
+	)
+
+	for i, op := range ops {
+		res.Results[i].Operation = op
+
+		sem <- struct{}{}
+		if allOrNothing && failed.Load() {
+			<-sem
+			res.Results[i].Status = portfolio.AssetOpSkipped
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := u.applyAssetOperation(ctx, portfolioId, op); err != nil {
+				failed.Store(true)
+				res.Results[i].Status = portfolio.AssetOpFailed
+				res.Results[i].Err = err
+				return
+			}
+
+			res.Results[i].Status = portfolio.AssetOpApplied
+		}()
+	}
+
+	wg.Wait()
+
+	res.Failed = failed.Load()
+	if !allOrNothing || !res.Failed {
+		return res, nil
+	}
+
+	// Откат не должен прерываться из-за отключения клиента
+	rollbackCtx := context.WithoutCancel(ctx)
+	res.RolledBack = true
+
+	for i := range res.Results {
+		result := &res.Results[i]
+		if result.Status != portfolio.AssetOpApplied && !maybeApplied(result) {
+			continue
+		}
+
+		if err := u.compensateAssetOperation(rollbackCtx, portfolioId, result.Operation, snapshot); err != nil {
+			log.Error("failed to roll back asset operation",
+				zap.Int("portfolio_id", portfolioId),
+				zap.String("op", result.Operation.Op),
+				zap.String("symbol", result.Operation.Symbol),
+				zap.Error(err),
+			)
+
+			result.Status = portfolio.AssetOpRollbackFailed
+			result.Err = err
+			res.RolledBack = false
+			continue
+		}
+
+		// Неудачная операция остаётся failed со своей ошибкой, её возможный эффект снят
+		if result.Status == portfolio.AssetOpApplied {
+			result.Status = portfolio.AssetOpRolledBack
+		}
+	}
+
+	return res, nil
+}
+
+// maybeApplied — операция завершилась ошибкой, но Portfolio Service мог её выполнить:
+// истёк дедлайн, оборвалось соединение или ответ не пришёл. Такие операции тоже откатываются,
+// восстановление по снимку идемпотентно.
+func maybeApplied(result *portfolio.AssetOperationResult) bool {
+	if result.Status != portfolio.AssetOpFailed {
+		return false
+	}
+
+	switch status.Code(result.Err) {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.Unknown, codes.Canceled, codes.Internal, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (u PortfolioUsecase) applyAssetOperation(ctx context.Context, portfolioId int, op portfolio.AssetOperation) error {
+	if op.Op == portfolio.AssetOpDelete {
+		return u.portfolioService.DeleteAsset(ctx, portfolioId, op.Symbol)
+	}
+
+	return u.portfolioService.UpsertAsset(ctx, portfolioId, op.Symbol, op.Amount)
+}
+
+// compensateAssetOperation возвращает актив к состоянию из снимка.
+func (u PortfolioUsecase) compensateAssetOperation(ctx context.Context, portfolioId int, op portfolio.AssetOperation,
+	snapshot portfolio.PortfolioContent) error {
+	amount, existed := snapshot.Assets[op.Symbol]
+	if existed {
+		return u.portfolioService.UpsertAsset(ctx, portfolioId, op.Symbol, amount)
+	}
+
+	if op.Op == portfolio.AssetOpDelete {
+		return nil
+	}
+
+	return u.portfolioService.DeleteAsset(ctx, portfolioId, op.Symbol)
+}
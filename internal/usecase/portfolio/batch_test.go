@@ -0,0 +1,199 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"sync"
+	"testing"
+)
+
+var testSymbols = fakeSymbolRegistry{
+	"BTC": {Ticker: "BTC", Precision: 8},
+	"ETH": {Ticker: "ETH", Precision: 8},
+	"SOL": {Ticker: "SOL", Precision: 8},
+	"ADA": {Ticker: "ADA", Precision: 6},
+}
+
+// batchService — Portfolio Service со снимком {BTC: 1, ETH: 5}; вызовы записываются как "upsert BTC 2",
+// ошибка возвращается для вызовов из errs
+type batchService struct {
+	mu    sync.Mutex
+	calls []string
+	errs  map[string]error
+}
+
+func (s *batchService) record(call string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, call)
+	return s.errs[call]
+}
+
+func (s *batchService) fake() *fakePortfolioService {
+	return &fakePortfolioService{
+		getPortfolioContentById: func(portfolioId int) (portfolio.PortfolioContent, error) {
+			return portfolio.PortfolioContent{Assets: map[string]float64{"BTC": 1, "ETH": 5}}, nil
+		},
+		upsertAsset: func(_ int, symbol string, amount float64) error {
+			return s.record(fmt.Sprintf("upsert %s %v", symbol, amount))
+		},
+		deleteAsset: func(_ int, symbol string) error {
+			return s.record("delete " + symbol)
+		},
+	}
+}
+
+func TestApplyAssetBatchRollback(t *testing.T) {
+	ops := []portfolio.AssetOperation{
+		{Op: portfolio.AssetOpUpsert, Symbol: "btc", Amount: 2},
+		{Op: portfolio.AssetOpUpsert, Symbol: "SOL", Amount: 3},
+		{Op: portfolio.AssetOpDelete, Symbol: "ETH"},
+		{Op: portfolio.AssetOpUpsert, Symbol: "ADA", Amount: 4},
+	}
+	applyCalls := []string{"upsert BTC 2", "upsert SOL 3", "delete ETH", "upsert ADA 4"}
+	rejected := status.Error(codes.InvalidArgument, "rejected")
+	timedOut := status.Error(codes.DeadlineExceeded, "deadline exceeded")
+
+	tests := []struct {
+		name           string
+		allOrNothing   bool
+		errs           map[string]error
+		wantStatuses   []string
+		wantFailed     bool
+		wantRolledBack bool
+		// Компенсирующие вызовы после applyCalls, порядок не важен
+		wantRollback []string
+	}{
+		{
+			name:         "all applied",
+			allOrNothing: true,
+			wantStatuses: []string{"applied", "applied", "applied", "applied"},
+		},
+		{
+			name:         "partial failure without all_or_nothing is kept",
+			allOrNothing: false,
+			errs:         map[string]error{"upsert ADA 4": rejected},
+			wantStatuses: []string{"applied", "applied", "applied", "failed"},
+			wantFailed:   true,
+		},
+		{
+			name:           "applied operations are restored from snapshot",
+			allOrNothing:   true,
+			errs:           map[string]error{"upsert ADA 4": rejected},
+			wantStatuses:   []string{"rolled_back", "rolled_back", "rolled_back", "failed"},
+			wantFailed:     true,
+			wantRolledBack: true,
+			wantRollback:   []string{"upsert BTC 1", "delete SOL", "upsert ETH 5"},
+		},
+		{
+			name:           "operation that may have been applied is compensated too",
+			allOrNothing:   true,
+			errs:           map[string]error{"upsert ADA 4": timedOut},
+			wantStatuses:   []string{"rolled_back", "rolled_back", "rolled_back", "failed"},
+			wantFailed:     true,
+			wantRolledBack: true,
+			wantRollback:   []string{"upsert BTC 1", "delete SOL", "upsert ETH 5", "delete ADA"},
+		},
+		{
+			name:         "failed compensation is reported",
+			allOrNothing: true,
+			errs: map[string]error{
+				"upsert ADA 4": rejected,
+				"delete SOL":   status.Error(codes.Unavailable, "unavailable"),
+			},
+			wantStatuses: []string{"rolled_back", "rollback_failed", "rolled_back", "failed"},
+			wantFailed:   true,
+			wantRollback: []string{"upsert BTC 1", "delete SOL", "upsert ETH 5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &batchService{errs: tt.errs}
+			u := NewPortfolioServiceUsecase(service.fake(), testSymbols, nil, nil)
+
+			res, err := u.ApplyAssetBatch(context.Background(), 1, ops, tt.allOrNothing)
+			if err != nil {
+				t.Fatalf("ApplyAssetBatch() error = %v", err)
+			}
+
+			statuses := make([]string, 0, len(res.Results))
+			for _, r := range res.Results {
+				statuses = append(statuses, r.Status)
+			}
+			if !slices.Equal(statuses, tt.wantStatuses) {
+				t.Errorf("statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+			if res.Failed != tt.wantFailed || res.RolledBack != tt.wantRolledBack {
+				t.Errorf("Failed/RolledBack = %v/%v, want %v/%v", res.Failed, res.RolledBack, tt.wantFailed, tt.wantRolledBack)
+			}
+
+			// Операции выполняются параллельно: сначала все применения, затем откат
+			if len(service.calls) < len(applyCalls) {
+				t.Fatalf("calls = %v, want at least %v", service.calls, applyCalls)
+			}
+			applied, rollback := service.calls[:len(applyCalls)], service.calls[len(applyCalls):]
+			if !sameCalls(applied, applyCalls) {
+				t.Errorf("apply calls = %v, want %v", applied, applyCalls)
+			}
+			if !sameCalls(rollback, tt.wantRollback) {
+				t.Errorf("rollback calls = %v, want %v", rollback, tt.wantRollback)
+			}
+		})
+	}
+}
+
+func TestApplyAssetBatchRejected(t *testing.T) {
+	tooLarge := make([]portfolio.AssetOperation, MaxAssetBatchSize+1)
+	for i := range tooLarge {
+		tooLarge[i] = portfolio.AssetOperation{Op: portfolio.AssetOpDelete, Symbol: fmt.Sprintf("S%d", i)}
+	}
+
+	tests := []struct {
+		name    string
+		ops     []portfolio.AssetOperation
+		wantErr error
+	}{
+		{
+			name:    "too many operations",
+			ops:     tooLarge,
+			wantErr: portfolio.ErrAssetBatchTooLarge,
+		},
+		{
+			name: "same symbol after normalization",
+			ops: []portfolio.AssetOperation{
+				{Op: portfolio.AssetOpUpsert, Symbol: "btc", Amount: 1},
+				{Op: portfolio.AssetOpDelete, Symbol: "BTC"},
+			},
+			wantErr: portfolio.ErrDuplicateBatchSymbol,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &batchService{}
+			u := NewPortfolioServiceUsecase(service.fake(), testSymbols, nil, nil)
+
+			_, err := u.ApplyAssetBatch(context.Background(), 1, tt.ops, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyAssetBatch() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(service.calls) != 0 {
+				t.Errorf("calls = %v, want none", service.calls)
+			}
+		})
+	}
+}
+
+func sameCalls(got, want []string) bool {
+	got, want = slices.Clone(got), slices.Clone(want)
+	slices.Sort(got)
+	slices.Sort(want)
+	return slices.Equal(got, want)
+}
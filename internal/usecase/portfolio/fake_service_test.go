@@ -0,0 +1,59 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"slices"
+	"strings"
+)
+
+// fakePortfolioService подменяет Portfolio Service в тестах usecase.
+// Методы без заданной функции паникуют через встроенный nil-интерфейс.
+type fakePortfolioService struct {
+	portfolio.PortfolioServiceContract
+
+	getPortfolioHistory     func(query portfolio.HistoryQuery) (portfolio.PortfolioHistory, error)
+	getPortfolioContentById func(portfolioId int) (portfolio.PortfolioContent, error)
+	upsertAsset             func(portfolioId int, symbol string, amount float64) error
+	deleteAsset             func(portfolioId int, symbol string) error
+}
+
+func (f *fakePortfolioService) GetPortfolioHistory(_ context.Context,
+	query portfolio.HistoryQuery) (portfolio.PortfolioHistory, error) {
+	return f.getPortfolioHistory(query)
+}
+
+func (f *fakePortfolioService) GetPortfolioContentById(_ context.Context,
+	portfolioId int) (portfolio.PortfolioContent, error) {
+	return f.getPortfolioContentById(portfolioId)
+}
+
+func (f *fakePortfolioService) UpsertAsset(_ context.Context, portfolioId int, symbol string, amount float64) error {
+	return f.upsertAsset(portfolioId, symbol, amount)
+}
+
+func (f *fakePortfolioService) DeleteAsset(_ context.Context, portfolioId int, symbol string) error {
+	return f.deleteAsset(portfolioId, symbol)
+}
+
+// fakeSymbolRegistry знает только перечисленные тикеры, псевдонимов нет; Search отдаёт тикеры по алфавиту
+type fakeSymbolRegistry map[string]portfolio.Symbol
+
+func (r fakeSymbolRegistry) Resolve(symbol string) (portfolio.Symbol, bool) {
+	s, ok := r[strings.ToUpper(symbol)]
+	return s, ok
+}
+
+func (r fakeSymbolRegistry) Search(prefix string, limit int) []portfolio.Symbol {
+	var found []portfolio.Symbol
+	for ticker, s := range r {
+		if strings.HasPrefix(ticker, strings.ToUpper(prefix)) {
+			found = append(found, s)
+		}
+	}
+	slices.SortFunc(found, func(a, b portfolio.Symbol) int {
+		return strings.Compare(a.Ticker, b.Ticker)
+	})
+
+	return found[:min(len(found), limit)]
+}
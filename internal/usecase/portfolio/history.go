@@ -0,0 +1,177 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultHistoryLimit = 100
+	MaxHistoryLimit     = 500
+
+	// Размер страницы сырых точек при запросе к Portfolio Service
+	historyRawPageSize = 1000
+	// Предел страниц сырых точек на одну страницу ответа
+	historyMaxRawPages = 50
+)
+
+var historyIntervals = map[string]time.Duration{
+	portfolio.HistoryInterval1h: time.Hour,
+	portfolio.HistoryInterval1d: 24 * time.Hour,
+	portfolio.HistoryInterval1w: 7 * 24 * time.Hour,
+}
+
+// GetPortfolioHistory прореживает историю до интервала и отдаёт её страницами по Limit интервалов.
+// Границы интервалов выровнены по UTC (неделя начинается с понедельника).
+// По умолчанию: To — текущий момент, From — Limit интервалов до To.
+func (u PortfolioUsecase) GetPortfolioHistory(ctx context.Context,
+	req portfolio.HistoryRequest) (portfolio.AggregatedHistory, error) {
+	if req.Interval == "" {
+		req.Interval = portfolio.HistoryInterval1d
+	}
+	if req.Aggregation == "" {
+		req.Aggregation = portfolio.HistoryAggregationLast
+	}
+	if req.Limit == 0 {
+		req.Limit = DefaultHistoryLimit
+	}
+
+	step, ok := historyIntervals[req.Interval]
+	if !ok || req.Limit < 0 || req.Limit > MaxHistoryLimit {
+		return portfolio.AggregatedHistory{}, portfolio.ErrInvalidHistoryRange
+	}
+
+	if req.To.IsZero() {
+		req.To = time.Now().UTC()
+	}
+	if req.From.IsZero() {
+		req.From = req.To.Add(-time.Duration(req.Limit) * step)
+	}
+	if !req.From.Before(req.To) {
+		return portfolio.AggregatedHistory{}, portfolio.ErrInvalidHistoryRange
+	}
+
+	start := req.From.UTC().Truncate(step)
+	if req.Cursor != "" {
+		cursor, err := decodeHistoryCursor(req.Cursor)
+		if err != nil || cursor.Before(start) || !cursor.Before(req.To) {
+			return portfolio.AggregatedHistory{}, portfolio.ErrInvalidCursor
+		}
+		start = cursor.Truncate(step)
+	}
+
+	end := start.Add(time.Duration(req.Limit) * step)
+	if end.After(req.To) {
+		end = req.To
+	}
+
+	// Первый интервал не захватывает точки раньше From
+	rawFrom := start
+	if rawFrom.Before(req.From) {
+		rawFrom = req.From
+	}
+
+	points, err := u.fetchHistory(ctx, portfolio.HistoryQuery{
+		PortfolioId: req.PortfolioId,
+		From:        rawFrom,
+		To:          end,
+		Symbols:     u.normalizeFilter(req.Symbols),
+		PageSize:    historyRawPageSize,
+	})
+	if err != nil {
+		return portfolio.AggregatedHistory{}, err
+	}
+
+	res := portfolio.AggregatedHistory{
+		Interval:    req.Interval,
+		Aggregation: req.Aggregation,
+		Buckets:     make(map[string][]portfolio.HistoryBucket, len(points)),
+	}
+
+	for symbol, symbolPoints := range points {
+		res.Buckets[symbol] = aggregateHistory(symbolPoints, step, req.Aggregation)
+	}
+
+	if end.Before(req.To) {
+		res.NextCursor = encodeHistoryCursor(end)
+	}
+
+	return res, nil
+}
+
+// fetchHistory выбирает все сырые точки окна, проходя по страницам Portfolio Service.
+func (u PortfolioUsecase) fetchHistory(ctx context.Context,
+	query portfolio.HistoryQuery) (map[string][]portfolio.PricePoint, error) {
+	points := make(map[string][]portfolio.PricePoint)
+
+	for range historyMaxRawPages {
+		page, err := u.portfolioService.GetPortfolioHistory(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for symbol, symbolPoints := range page.History {
+			points[symbol] = append(points[symbol], symbolPoints...)
+		}
+
+		if page.NextCursor == "" {
+			return points, nil
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	return nil, portfolio.ErrHistoryTooLarge
+}
+
+func aggregateHistory(points []portfolio.PricePoint, step time.Duration, aggregation string) []portfolio.HistoryBucket {
+	slices.SortStableFunc(points, func(a, b portfolio.PricePoint) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	buckets := make([]portfolio.HistoryBucket, 0)
+	for _, p := range points {
+		bucketStart := p.Timestamp.Truncate(step)
+
+		if n := len(buckets); n > 0 && buckets[n-1].Start.Equal(bucketStart) {
+			b := &buckets[n-1]
+			b.Close = p.Value
+			if aggregation == portfolio.HistoryAggregationOHLC {
+				b.High = max(b.High, p.Value)
+				b.Low = min(b.Low, p.Value)
+			}
+			continue
+		}
+
+		b := portfolio.HistoryBucket{Start: bucketStart, Close: p.Value}
+		if aggregation == portfolio.HistoryAggregationOHLC {
+			b.Open, b.High, b.Low = p.Value, p.Value, p.Value
+		}
+		buckets = append(buckets, b)
+	}
+
+	return buckets
+}
+
+// Курсор — начало следующей страницы в unix-секундах, непрозрачный для клиента
+func encodeHistoryCursor(t time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(t.Unix(), 10)))
+}
+
+func decodeHistoryCursor(cursor string) (time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+
+	sec, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse cursor: %w", err)
+	}
+
+	return time.Unix(sec, 0).UTC(), nil
+}
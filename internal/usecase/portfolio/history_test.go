@@ -0,0 +1,201 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHistoryCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+	}{
+		{name: "epoch", t: time.Unix(0, 0).UTC()},
+		{name: "interval start", t: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "before epoch", t: time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC)},
+		{name: "non-UTC location", t: time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("MSK", 3*3600))},
+		{name: "sub-second precision is dropped", t: time.Date(2024, 3, 1, 0, 0, 0, 999, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeHistoryCursor(encodeHistoryCursor(tt.t))
+			if err != nil {
+				t.Fatalf("decodeHistoryCursor: %v", err)
+			}
+
+			want := tt.t.Truncate(time.Second).UTC()
+			if !got.Equal(want) || got.Location() != time.UTC {
+				t.Errorf("round trip = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecodeHistoryCursorInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "!!!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte("1700000000"))},
+		{name: "not a number", cursor: base64.RawURLEncoding.EncodeToString([]byte("yesterday"))},
+		{name: "fractional", cursor: base64.RawURLEncoding.EncodeToString([]byte("1.5"))},
+		{name: "empty payload", cursor: base64.RawURLEncoding.EncodeToString(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeHistoryCursor(tt.cursor); err == nil {
+				t.Errorf("decodeHistoryCursor(%q) error = nil, want error", tt.cursor)
+			}
+		})
+	}
+}
+
+func TestGetPortfolioHistoryCursor(t *testing.T) {
+	day := 24 * time.Hour
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(5 * day)
+
+	tests := []struct {
+		name       string
+		cursor     string
+		wantErr    error
+		wantFrom   time.Time
+		wantTo     time.Time
+		wantCursor string
+	}{
+		{
+			name:       "first page",
+			wantFrom:   from,
+			wantTo:     from.Add(2 * day),
+			wantCursor: encodeHistoryCursor(from.Add(2 * day)),
+		},
+		{
+			name:       "cursor inside range",
+			cursor:     encodeHistoryCursor(from.Add(2 * day)),
+			wantFrom:   from.Add(2 * day),
+			wantTo:     from.Add(4 * day),
+			wantCursor: encodeHistoryCursor(from.Add(4 * day)),
+		},
+		{
+			name:     "last page is cut at To",
+			cursor:   encodeHistoryCursor(from.Add(4 * day)),
+			wantFrom: from.Add(4 * day),
+			wantTo:   to,
+		},
+		{
+			name:     "cursor is aligned to interval",
+			cursor:   encodeHistoryCursor(from.Add(2*day + time.Hour)),
+			wantFrom: from.Add(2 * day),
+			wantTo:   from.Add(4 * day),
+			// Следующая страница продолжается с границы интервала
+			wantCursor: encodeHistoryCursor(from.Add(4 * day)),
+		},
+		{
+			name:    "cursor before From",
+			cursor:  encodeHistoryCursor(from.Add(-day)),
+			wantErr: portfolio.ErrInvalidCursor,
+		},
+		{
+			name:    "cursor at To",
+			cursor:  encodeHistoryCursor(to),
+			wantErr: portfolio.ErrInvalidCursor,
+		},
+		{
+			name:    "cursor after To",
+			cursor:  encodeHistoryCursor(to.Add(day)),
+			wantErr: portfolio.ErrInvalidCursor,
+		},
+		{
+			name:    "malformed cursor",
+			cursor:  "garbage!",
+			wantErr: portfolio.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []portfolio.HistoryQuery
+			service := &fakePortfolioService{
+				getPortfolioHistory: func(query portfolio.HistoryQuery) (portfolio.PortfolioHistory, error) {
+					queries = append(queries, query)
+					return portfolio.PortfolioHistory{}, nil
+				},
+			}
+			u := NewPortfolioServiceUsecase(service, nil, nil, nil)
+
+			res, err := u.GetPortfolioHistory(context.Background(), portfolio.HistoryRequest{
+				PortfolioId: 1,
+				From:        from,
+				To:          to,
+				Interval:    portfolio.HistoryInterval1d,
+				Cursor:      tt.cursor,
+				Limit:       2,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPortfolioHistory() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(queries) != 0 {
+					t.Errorf("Portfolio Service called %d times on invalid cursor", len(queries))
+				}
+				return
+			}
+
+			if len(queries) != 1 {
+				t.Fatalf("Portfolio Service called %d times, want 1", len(queries))
+			}
+			if !queries[0].From.Equal(tt.wantFrom) || !queries[0].To.Equal(tt.wantTo) {
+				t.Errorf("query window = [%v, %v), want [%v, %v)", queries[0].From, queries[0].To, tt.wantFrom, tt.wantTo)
+			}
+			if res.NextCursor != tt.wantCursor {
+				t.Errorf("NextCursor = %q, want %q", res.NextCursor, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestGetPortfolioHistorySymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbols []string
+		want    []string
+	}{
+		{name: "no filter", want: nil},
+		{name: "known symbols resolve to tickers", symbols: []string{"btc", "Eth"}, want: []string{"BTC", "ETH"}},
+		{name: "spellings of one ticker are merged", symbols: []string{"btc", "BTC", "eth"}, want: []string{"BTC", "ETH"}},
+		{name: "unknown symbol is kept as is", symbols: []string{"oldcoin", "sol"}, want: []string{"oldcoin", "SOL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			service := &fakePortfolioService{
+				getPortfolioHistory: func(query portfolio.HistoryQuery) (portfolio.PortfolioHistory, error) {
+					got = query.Symbols
+					return portfolio.PortfolioHistory{}, nil
+				},
+			}
+			u := NewPortfolioServiceUsecase(service, testSymbols, nil, nil)
+
+			_, err := u.GetPortfolioHistory(context.Background(), portfolio.HistoryRequest{
+				PortfolioId: 1,
+				Symbols:     tt.symbols,
+			})
+			if err != nil {
+				t.Fatalf("GetPortfolioHistory: %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Symbols = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
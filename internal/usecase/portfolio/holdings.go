@@ -0,0 +1,176 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxExportHoldings — позиций в одной выгрузке. Portfolio Service отдаёт содержимое портфеля целиком,
+// без страниц, поэтому выгрузка собирается в памяти и ограничена этим размером.
+const MaxExportHoldings = 1000
+
+// GetHoldings возвращает позиции портфеля по алфавиту. С withProfit к каждой позиции
+// присоединяется прибыль из GetPortfolioProfit; активы без данных о прибыли остаются без неё.
+// Портфель больше MaxExportHoldings не выгружается (portfolio.ErrExportTooLarge).
+func (u PortfolioUsecase) GetHoldings(ctx context.Context, portfolioId int, withProfit bool) ([]portfolio.Holding, error) {
+	content, err := u.portfolioService.GetPortfolioContentById(ctx, portfolioId)
+	if err != nil {
+		return nil, err
+	}
+	if len(content.Assets) > MaxExportHoldings {
+		return nil, fmt.Errorf("%w: %d assets, at most %d", portfolio.ErrExportTooLarge,
+			len(content.Assets), MaxExportHoldings)
+	}
+
+	profits := make(map[string]portfolio.AssetProfit)
+	if withProfit {
+		assets, err := u.portfolioService.GetPortfolioProfit(ctx, portfolioId)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range assets {
+			profits[a.Symbol] = a
+		}
+	}
+
+	holdings := make([]portfolio.Holding, 0, len(content.Assets))
+	for symbol, amount := range content.Assets {
+		h := portfolio.Holding{Symbol: symbol, Amount: amount}
+		if p, ok := profits[symbol]; ok {
+			h.Profit = &p
+		}
+		holdings = append(holdings, h)
+	}
+
+	slices.SortFunc(holdings, func(a, b portfolio.Holding) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+
+	return holdings, nil
+}
+
+// ImportHoldings приводит символы к каноническим тикерам, сравнивает позиции из файла с портфелем и, если это не dry run,
+// применяет разницу одним пакетом all_or_nothing. Нулевое количество удаляет актив;
+// с replace удаляются и активы, которых нет в файле. Разница больше MaxAssetBatchSize не применяется
+// (portfolio.ErrAssetBatchTooLarge), dry run показывает её целиком.
+func (u PortfolioUsecase) ImportHoldings(ctx context.Context, portfolioId int, holdings []portfolio.Holding,
+	replace, dryRun bool) (portfolio.ImportResult, error) {
+	holdings, err := u.normalizeHoldings(holdings)
+	if err != nil {
+		return portfolio.ImportResult{}, err
+	}
+
+	content, err := u.portfolioService.GetPortfolioContentById(ctx, portfolioId)
+	if err != nil {
+		return portfolio.ImportResult{}, err
+	}
+
+	var res portfolio.ImportResult
+	inFile := make(map[string]struct{}, len(holdings))
+
+	for _, h := range holdings {
+		inFile[h.Symbol] = struct{}{}
+		before, exists := content.Assets[h.Symbol]
+
+		switch {
+		case h.Amount == 0 && exists:
+			res.Changes = append(res.Changes, portfolio.HoldingChange{
+				Symbol: h.Symbol, Action: portfolio.HoldingRemove, Before: before,
+			})
+		case h.Amount == 0:
+			res.Unchanged++
+		case !exists:
+			res.Changes = append(res.Changes, portfolio.HoldingChange{
+				Symbol: h.Symbol, Action: portfolio.HoldingAdd, After: h.Amount,
+			})
+		case before != h.Amount:
+			res.Changes = append(res.Changes, portfolio.HoldingChange{
+				Symbol: h.Symbol, Action: portfolio.HoldingUpdate, Before: before, After: h.Amount,
+			})
+		default:
+			res.Unchanged++
+		}
+	}
+
+	if replace {
+		for symbol, before := range content.Assets {
+			if _, ok := inFile[symbol]; !ok {
+				res.Changes = append(res.Changes, portfolio.HoldingChange{
+					Symbol: symbol, Action: portfolio.HoldingRemove, Before: before,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(res.Changes, func(a, b portfolio.HoldingChange) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+
+	if dryRun || len(res.Changes) == 0 {
+		return res, nil
+	}
+
+	if len(res.Changes) > MaxAssetBatchSize {
+		return portfolio.ImportResult{}, fmt.Errorf("%w: import changes %d assets, at most %d",
+			portfolio.ErrAssetBatchTooLarge, len(res.Changes), MaxAssetBatchSize)
+	}
+
+	ops := make([]portfolio.AssetOperation, 0, len(res.Changes))
+	for _, change := range res.Changes {
+		op := portfolio.AssetOperation{Op: portfolio.AssetOpUpsert, Symbol: change.Symbol, Amount: change.After}
+		if change.Action == portfolio.HoldingRemove {
+			op = portfolio.AssetOperation{Op: portfolio.AssetOpDelete, Symbol: change.Symbol}
+		}
+		ops = append(ops, op)
+	}
+
+	batch, err := u.ApplyAssetBatch(ctx, portfolioId, ops, true)
+	if err != nil {
+		return portfolio.ImportResult{}, err
+	}
+	res.Batch = &batch
+
+	return res, nil
+}
+
+// normalizeHoldings возвращает *portfolio.AssetValidationError, в том числе когда разные
+// написания одного актива (btc и XBT) встречаются в файле дважды.
+func (u PortfolioUsecase) normalizeHoldings(holdings []portfolio.Holding) ([]portfolio.Holding, error) {
+	normalized := make([]portfolio.Holding, 0, len(holdings))
+	seen := make(map[string]struct{}, len(holdings))
+
+	var violations []portfolio.AssetViolation
+	for i, h := range holdings {
+		if h.Amount == 0 {
+			h.Symbol = u.normalizeDelete(h.Symbol)
+		} else {
+			var holdingViolations []portfolio.AssetViolation
+			h.Symbol, holdingViolations = u.normalizeWrite(i, h.Symbol, h.Amount)
+			if len(holdingViolations) > 0 {
+				violations = append(violations, holdingViolations...)
+				continue
+			}
+		}
+
+		if _, ok := seen[h.Symbol]; ok {
+			violations = append(violations, portfolio.AssetViolation{
+				Index:   i,
+				Field:   "symbol",
+				Message: fmt.Sprintf("%s is listed more than once", h.Symbol),
+			})
+			continue
+		}
+		seen[h.Symbol] = struct{}{}
+
+		normalized = append(normalized, h)
+	}
+
+	if len(violations) > 0 {
+		return nil, &portfolio.AssetValidationError{Violations: violations}
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,44 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGetHoldingsLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		assets  int
+		wantErr error
+	}{
+		{name: "at the limit", assets: MaxExportHoldings},
+		{name: "over the limit", assets: MaxExportHoldings + 1, wantErr: portfolio.ErrExportTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assets := make(map[string]float64, tt.assets)
+			for i := range tt.assets {
+				assets[fmt.Sprintf("C%d", i)] = 1
+			}
+
+			service := &fakePortfolioService{
+				getPortfolioContentById: func(int) (portfolio.PortfolioContent, error) {
+					return portfolio.PortfolioContent{Assets: assets}, nil
+				},
+			}
+			u := NewPortfolioServiceUsecase(service, nil, nil, nil)
+
+			holdings, err := u.GetHoldings(context.Background(), 1, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetHoldings() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(holdings) != tt.assets {
+				t.Errorf("holdings = %d, want %d", len(holdings), tt.assets)
+			}
+		})
+	}
+}
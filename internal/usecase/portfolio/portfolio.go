@@ -7,36 +7,85 @@ import (
 
 type PortfolioUsecase struct {
 	portfolioService portfolio.PortfolioServiceContract
+	symbols          portfolio.SymbolRegistryContract
+	profiles         portfolio.ProfileLookupContract
+	profileCache     portfolio.ProfileCacheContract
 }
 
-func NewPortfolioServiceUsecase(portfolioService portfolio.PortfolioServiceContract) *PortfolioUsecase {
-	return &PortfolioUsecase{portfolioService: portfolioService}
+func NewPortfolioServiceUsecase(portfolioService portfolio.PortfolioServiceContract,
+	symbols portfolio.SymbolRegistryContract, profiles portfolio.ProfileLookupContract,
+	profileCache portfolio.ProfileCacheContract) *PortfolioUsecase {
+	return &PortfolioUsecase{
+		portfolioService: portfolioService,
+		symbols:          symbols,
+		profiles:         profiles,
+		profileCache:     profileCache,
+	}
 }
 
 func (u PortfolioUsecase) CreateNewPortfolio(ctx context.Context, name string, isPublic bool) (portfolio.Portfolio, error) {
 	return u.portfolioService.CreateNewPortfolio(ctx, name, isPublic)
 }
 
+func (u PortfolioUsecase) UpdatePortfolio(ctx context.Context, portfolioId int,
+	update portfolio.PortfolioUpdate) (portfolio.Portfolio, error) {
+	return u.portfolioService.UpdatePortfolio(ctx, portfolioId, update)
+}
+
+func (u PortfolioUsecase) DeletePortfolio(ctx context.Context, portfolioId int) error {
+	return u.portfolioService.DeletePortfolio(ctx, portfolioId)
+}
+
 func (u PortfolioUsecase) GetPortfolioContentById(ctx context.Context, portfolioID int) (portfolio.PortfolioContent, error) {
 	return u.portfolioService.GetPortfolioContentById(ctx, portfolioID)
 }
 
+// UpsertAsset записывает актив под каноническим тикером; неизвестный символ — *portfolio.AssetValidationError.
 func (u PortfolioUsecase) UpsertAsset(ctx context.Context, portfolioId int, symbol string, amount float64) error {
-	return u.portfolioService.UpsertAsset(ctx, portfolioId, symbol, amount)
+	ticker, violations := u.normalizeWrite(0, symbol, amount)
+	if len(violations) > 0 {
+		return &portfolio.AssetValidationError{Violations: violations}
+	}
+
+	return u.portfolioService.UpsertAsset(ctx, portfolioId, ticker, amount)
 }
 
 func (u PortfolioUsecase) DeleteAsset(ctx context.Context, portfolioId int, symbol string) error {
-	return u.portfolioService.DeleteAsset(ctx, portfolioId, symbol)
+	return u.portfolioService.DeleteAsset(ctx, portfolioId, u.normalizeDelete(symbol))
 }
 
 func (u PortfolioUsecase) GetAllPortfolios(ctx context.Context) ([]portfolio.Portfolio, error) {
 	return u.portfolioService.GetAllPortfolios(ctx)
 }
 
-func (u PortfolioUsecase) GetPortfolioHistory(ctx context.Context, id, page, pageSize int32) (portfolio.PortfolioHistory, error) {
-	return u.portfolioService.GetPortfolioHistory(ctx, id, page, pageSize)
-}
+// GetPortfolioProfit дополняет прибыль по активам итогами портфеля.
+func (u PortfolioUsecase) GetPortfolioProfit(ctx context.Context, portfolioId int) (portfolio.PortfolioProfit, error) {
+	assets, err := u.portfolioService.GetPortfolioProfit(ctx, portfolioId)
+	if err != nil {
+		return portfolio.PortfolioProfit{}, err
+	}
+
+	res := portfolio.PortfolioProfit{Assets: assets}
+
+	for i := range assets {
+		asset := &assets[i]
+
+		res.TotalInvested += asset.Invested
+		res.CurrentValue += asset.CurrentValue
+		res.Profit += asset.Profit
+
+		if res.Best == nil || asset.Profit > res.Best.Profit {
+			res.Best = asset
+		}
+		if res.Worst == nil || asset.Profit < res.Worst.Profit {
+			res.Worst = asset
+		}
+	}
+
+	if res.TotalInvested != 0 {
+		roi := res.Profit / res.TotalInvested * 100
+		res.ROIPercent = &roi
+	}
 
-func (u PortfolioUsecase) GetPublicPortfolios(ctx context.Context, userId int) ([]portfolio.PublicPortfolio, error) {
-	return u.portfolioService.GetPublicPortfolios(ctx, userId)
+	return res, nil
 }
@@ -0,0 +1,68 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+)
+
+// GetPublicPortfolios находит владельца по username и отдаёт его публичные портфели.
+// Неизвестный username — portfolio.ErrUserNotFound.
+func (u PortfolioUsecase) GetPublicPortfolios(ctx context.Context, username string) (portfolio.PublicPortfolios, error) {
+	owner, err := u.resolveProfile(ctx, username)
+	if err != nil {
+		return portfolio.PublicPortfolios{}, err
+	}
+
+	// Portfolio Service пока ищет портфели по числовому id владельца
+	userId, err := strconv.Atoi(owner.UserId)
+	if err != nil {
+		return portfolio.PublicPortfolios{}, fmt.Errorf("user %s has non-numeric id %q", owner.Username, owner.UserId)
+	}
+
+	portfolios, err := u.portfolioService.GetPublicPortfolios(ctx, userId)
+	if err != nil {
+		return portfolio.PublicPortfolios{}, err
+	}
+
+	return portfolio.PublicPortfolios{Owner: owner, Portfolios: portfolios}, nil
+}
+
+// resolveProfile сначала смотрит в кеш; недоступный кеш не мешает запросу в Auth Service.
+func (u PortfolioUsecase) resolveProfile(ctx context.Context, username string) (portfolio.PublicProfile, error) {
+	log := logger.FromContext(ctx)
+
+	cached, err := u.profileCache.Get(ctx, username)
+	switch {
+	case errors.Is(err, portfolio.ErrUserNotFound):
+		return portfolio.PublicProfile{}, err
+	case err != nil:
+		log.Warn("failed to read profile cache", zap.String("username", username), zap.Error(err))
+	case cached != nil:
+		return *cached, nil
+	}
+
+	profile, err := u.profiles.LookupUserByUsername(ctx, username)
+	if status.Code(err) == codes.NotFound {
+		if err := u.profileCache.SetNotFound(ctx, username); err != nil {
+			log.Warn("failed to cache missing profile", zap.String("username", username), zap.Error(err))
+		}
+
+		return portfolio.PublicProfile{}, portfolio.ErrUserNotFound
+	}
+	if err != nil {
+		return portfolio.PublicProfile{}, err
+	}
+
+	if err := u.profileCache.Set(ctx, username, profile); err != nil {
+		log.Warn("failed to cache profile", zap.String("username", username), zap.Error(err))
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,180 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"crypto_analyzer-api_gateway/internal/infrastructure/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Одновременно загружаемых портфелей для сводки
+	summaryConcurrency = 4
+	// Дедлайн каждого вызова Portfolio Service; медленный портфель попадает в сводку как partial/failed
+	summaryCallTimeout = 3 * time.Second
+	// Общий бюджет сводки; портфели, не успевшие загрузиться, помечаются failed
+	summaryTotalTimeout = 10 * time.Second
+)
+
+type portfolioSummaryLoad struct {
+	status  portfolio.PortfolioSummaryStatus
+	content portfolio.PortfolioContent
+	profits []portfolio.AssetProfit
+}
+
+// GetPortfoliosSummary загружает содержимое и прибыль всех портфелей, доступных по canAccess,
+// и складывает позиции по символам. Ошибка возвращается только если не удалось получить список портфелей.
+func (u PortfolioUsecase) GetPortfoliosSummary(ctx context.Context,
+	canAccess func(portfolioId int32) bool) (portfolio.PortfoliosSummary, error) {
+	all, err := u.portfolioService.GetAllPortfolios(ctx)
+	if err != nil {
+		return portfolio.PortfoliosSummary{}, err
+	}
+
+	portfolios := make([]portfolio.Portfolio, 0, len(all))
+	for _, p := range all {
+		if canAccess(p.Id) {
+			portfolios = append(portfolios, p)
+		}
+	}
+
+	loads := make([]portfolioSummaryLoad, len(portfolios))
+
+	ctx, cancel := context.WithTimeout(ctx, summaryTotalTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, summaryConcurrency)
+
+	for i, p := range portfolios {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// Бюджет исчерпан — оставшиеся портфели не запрашиваются
+			err := status.FromContextError(ctx.Err()).Err()
+			loads[i] = portfolioSummaryLoad{status: portfolio.PortfolioSummaryStatus{
+				Portfolio: p, Status: portfolio.SummaryFailed, ContentErr: err, ProfitErr: err,
+			}}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			loads[i] = u.loadPortfolioSummary(ctx, p)
+		}()
+	}
+
+	wg.Wait()
+
+	return u.mergePortfolioSummaries(loads), nil
+}
+
+func (u PortfolioUsecase) loadPortfolioSummary(ctx context.Context, p portfolio.Portfolio) portfolioSummaryLoad {
+	log := logger.FromContext(ctx)
+	load := portfolioSummaryLoad{status: portfolio.PortfolioSummaryStatus{Portfolio: p}}
+
+	// Содержимое и прибыль запрашиваются параллельно: портфель занимает слот не дольше summaryCallTimeout
+	callCtx, cancel := context.WithTimeout(ctx, summaryCallTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		load.content, load.status.ContentErr = u.portfolioService.GetPortfolioContentById(callCtx, int(p.Id))
+	}()
+
+	load.profits, load.status.ProfitErr = u.portfolioService.GetPortfolioProfit(callCtx, int(p.Id))
+	wg.Wait()
+
+	switch {
+	case load.status.ContentErr != nil && load.status.ProfitErr != nil:
+		load.status.Status = portfolio.SummaryFailed
+	case load.status.ContentErr != nil || load.status.ProfitErr != nil:
+		load.status.Status = portfolio.SummaryPartial
+	default:
+		load.status.Status = portfolio.SummaryOK
+	}
+
+	if load.status.Status != portfolio.SummaryOK {
+		log.Warn("portfolio summary degraded",
+			zap.Int32("portfolio_id", p.Id),
+			zap.NamedError("content_error", load.status.ContentErr),
+			zap.NamedError("profit_error", load.status.ProfitErr),
+		)
+	}
+
+	return load
+}
+
+// mergePortfolioSummaries складывает позиции. Количество берётся из содержимого портфеля,
+// а если оно не загрузилось — из данных о прибыли. Символы приводятся к тикерам реестра,
+// чтобы позиции, записанные в разном написании, складывались в одну.
+func (u PortfolioUsecase) mergePortfolioSummaries(loads []portfolioSummaryLoad) portfolio.PortfoliosSummary {
+	var res portfolio.PortfoliosSummary
+	holdings := make(map[string]*portfolio.SummaryHolding)
+
+	holding := func(symbol string, portfolioId int32) *portfolio.SummaryHolding {
+		symbol = u.normalizeDelete(symbol)
+		h, ok := holdings[symbol]
+		if !ok {
+			h = &portfolio.SummaryHolding{Symbol: symbol}
+			holdings[symbol] = h
+		}
+		if !slices.Contains(h.PortfolioIds, portfolioId) {
+			h.PortfolioIds = append(h.PortfolioIds, portfolioId)
+		}
+		return h
+	}
+
+	for _, load := range loads {
+		status := load.status
+		id := status.Portfolio.Id
+
+		if status.ContentErr == nil {
+			for symbol, amount := range load.content.Assets {
+				holding(symbol, id).Amount += amount
+			}
+		}
+
+		if status.ProfitErr == nil {
+			for _, a := range load.profits {
+				h := holding(a.Symbol, id)
+				if status.ContentErr != nil {
+					h.Amount += a.Amount
+				}
+				h.Invested += a.Invested
+				h.CurrentValue += a.CurrentValue
+				h.Profit += a.Profit
+
+				status.CurrentValue += a.CurrentValue
+				status.Profit += a.Profit
+				res.TotalInvested += a.Invested
+			}
+		}
+
+		res.TotalValue += status.CurrentValue
+		res.TotalProfit += status.Profit
+		res.Partial = res.Partial || status.Status != portfolio.SummaryOK
+		res.Portfolios = append(res.Portfolios, status)
+	}
+
+	res.Holdings = make([]portfolio.SummaryHolding, 0, len(holdings))
+	for _, h := range holdings {
+		slices.Sort(h.PortfolioIds)
+		res.Holdings = append(res.Holdings, *h)
+	}
+	slices.SortFunc(res.Holdings, func(a, b portfolio.SummaryHolding) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+
+	return res
+}
@@ -0,0 +1,54 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergePortfolioSummariesNormalizesSymbols(t *testing.T) {
+	u := NewPortfolioServiceUsecase(nil, testSymbols, nil, nil)
+
+	loads := []portfolioSummaryLoad{
+		{
+			status:  portfolio.PortfolioSummaryStatus{Portfolio: portfolio.Portfolio{Id: 1}, Status: portfolio.SummaryOK},
+			content: portfolio.PortfolioContent{Assets: map[string]float64{"BTC": 1, "oldcoin": 3}},
+			profits: []portfolio.AssetProfit{
+				{Symbol: "BTC", Amount: 1, Invested: 100, CurrentValue: 150, Profit: 50},
+			},
+		},
+		{
+			// Позиции, записанные до реестра, в нижнем регистре
+			status:  portfolio.PortfolioSummaryStatus{Portfolio: portfolio.Portfolio{Id: 2}, Status: portfolio.SummaryOK},
+			content: portfolio.PortfolioContent{Assets: map[string]float64{"btc": 2}},
+			profits: []portfolio.AssetProfit{
+				{Symbol: "btc", Amount: 2, Invested: 200, CurrentValue: 300, Profit: 100},
+			},
+		},
+		{
+			// Содержимое не загрузилось: количество берётся из прибыли
+			status: portfolio.PortfolioSummaryStatus{
+				Portfolio:  portfolio.Portfolio{Id: 3},
+				Status:     portfolio.SummaryPartial,
+				ContentErr: errors.New("timeout"),
+			},
+			profits: []portfolio.AssetProfit{
+				{Symbol: "Btc", Amount: 4, Invested: 400, CurrentValue: 600, Profit: 200},
+			},
+		},
+	}
+
+	res := u.mergePortfolioSummaries(loads)
+
+	want := []portfolio.SummaryHolding{
+		{Symbol: "BTC", Amount: 7, Invested: 700, CurrentValue: 1050, Profit: 350, PortfolioIds: []int32{1, 2, 3}},
+		{Symbol: "oldcoin", Amount: 3, PortfolioIds: []int32{1}},
+	}
+	if !reflect.DeepEqual(res.Holdings, want) {
+		t.Errorf("Holdings = %+v, want %+v", res.Holdings, want)
+	}
+	if res.TotalValue != 1050 || res.TotalProfit != 350 || res.TotalInvested != 700 || !res.Partial {
+		t.Errorf("totals = %v/%v/%v partial %v", res.TotalValue, res.TotalProfit, res.TotalInvested, res.Partial)
+	}
+}
@@ -0,0 +1,92 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const maxSymbolSuggestions = 3
+
+func (u PortfolioUsecase) SearchSymbols(prefix string, limit int) []portfolio.Symbol {
+	return u.symbols.Search(prefix, limit)
+}
+
+// normalizeWrite приводит символ к каноническому тикеру и проверяет точность количества.
+// Неизвестный символ — нарушение с подсказками похожих тикеров.
+func (u PortfolioUsecase) normalizeWrite(index int, symbol string, amount float64) (string, []portfolio.AssetViolation) {
+	s, ok := u.symbols.Resolve(symbol)
+	if !ok {
+		message := fmt.Sprintf("unknown symbol %q", symbol)
+		if suggestions := u.suggestSymbols(symbol); len(suggestions) > 0 {
+			message += ", did you mean " + strings.Join(suggestions, ", ") + "?"
+		}
+
+		return symbol, []portfolio.AssetViolation{{Index: index, Field: "symbol", Message: message}}
+	}
+
+	if decimals(amount) > s.Precision {
+		return s.Ticker, []portfolio.AssetViolation{{
+			Index:   index,
+			Field:   "amount",
+			Message: fmt.Sprintf("%s supports at most %d decimal places", s.Ticker, s.Precision),
+		}}
+	}
+
+	return s.Ticker, nil
+}
+
+// normalizeDelete не отклоняет неизвестные символы: удалить позицию, записанную до появления
+// реестра, должно быть можно.
+func (u PortfolioUsecase) normalizeDelete(symbol string) string {
+	if s, ok := u.symbols.Resolve(symbol); ok {
+		return s.Ticker
+	}
+
+	return symbol
+}
+
+// normalizeFilter приводит символы фильтра чтения к тикерам, как normalizeDelete, и убирает повторы
+func (u PortfolioUsecase) normalizeFilter(symbols []string) []string {
+	if len(symbols) == 0 {
+		return symbols
+	}
+
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = u.normalizeDelete(symbol)
+		if !slices.Contains(normalized, symbol) {
+			normalized = append(normalized, symbol)
+		}
+	}
+
+	return normalized
+}
+
+func (u PortfolioUsecase) suggestSymbols(symbol string) []string {
+	for n := len(symbol) - 1; n > 0; n-- {
+		found := u.symbols.Search(symbol[:n], maxSymbolSuggestions)
+		if len(found) == 0 {
+			continue
+		}
+
+		tickers := make([]string, 0, len(found))
+		for _, s := range found {
+			tickers = append(tickers, s.Ticker)
+		}
+		return tickers
+	}
+
+	return nil
+}
+
+func decimals(amount float64) int {
+	s := strconv.FormatFloat(amount, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+
+	return 0
+}
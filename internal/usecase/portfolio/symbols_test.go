@@ -0,0 +1,128 @@
+package portfolio
+
+import (
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"reflect"
+	"testing"
+)
+
+func TestDecimals(t *testing.T) {
+	// Переменная, чтобы сумма не свернулась в точную константу 0.3 при компиляции
+	tenth := 0.1
+
+	tests := []struct {
+		amount float64
+		want   int
+	}{
+		{amount: 0, want: 0},
+		{amount: 42, want: 0},
+		{amount: 1e21, want: 0},
+		{amount: 0.5, want: 1},
+		{amount: 1.25, want: 2},
+		{amount: -3.125, want: 3},
+		{amount: 0.00000001, want: 8},
+		{amount: 1e-9, want: 9},
+		{amount: tenth + 0.2, want: 17},
+	}
+
+	for _, tt := range tests {
+		if got := decimals(tt.amount); got != tt.want {
+			t.Errorf("decimals(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeWrite(t *testing.T) {
+	u := NewPortfolioServiceUsecase(nil, fakeSymbolRegistry{
+		"BTC":  {Ticker: "BTC", Precision: 8},
+		"BNB":  {Ticker: "BNB", Precision: 8},
+		"BUSD": {Ticker: "BUSD", Precision: 2},
+		"ETH":  {Ticker: "ETH", Precision: 8},
+	}, nil, nil)
+
+	tests := []struct {
+		name           string
+		symbol         string
+		amount         float64
+		wantSymbol     string
+		wantViolations []portfolio.AssetViolation
+	}{
+		{
+			name:       "canonical ticker",
+			symbol:     "btc",
+			amount:     0.12345678,
+			wantSymbol: "BTC",
+		},
+		{
+			name:       "precision limit is inclusive",
+			symbol:     "BUSD",
+			amount:     10.25,
+			wantSymbol: "BUSD",
+		},
+		{
+			name:       "too many decimal places",
+			symbol:     "busd",
+			amount:     10.255,
+			wantSymbol: "BUSD",
+			wantViolations: []portfolio.AssetViolation{
+				{Index: 3, Field: "amount", Message: "BUSD supports at most 2 decimal places"},
+			},
+		},
+		{
+			name:       "unknown symbol with suggestions",
+			symbol:     "BXX",
+			wantSymbol: "BXX",
+			wantViolations: []portfolio.AssetViolation{
+				{Index: 3, Field: "symbol", Message: `unknown symbol "BXX", did you mean BNB, BTC, BUSD?`},
+			},
+		},
+		{
+			name:       "suggestions use the longest matching prefix",
+			symbol:     "BTX",
+			wantSymbol: "BTX",
+			wantViolations: []portfolio.AssetViolation{
+				{Index: 3, Field: "symbol", Message: `unknown symbol "BTX", did you mean BTC?`},
+			},
+		},
+		{
+			name:       "unknown symbol without suggestions",
+			symbol:     "XRP",
+			wantSymbol: "XRP",
+			wantViolations: []portfolio.AssetViolation{
+				{Index: 3, Field: "symbol", Message: `unknown symbol "XRP"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbol, violations := u.normalizeWrite(3, tt.symbol, tt.amount)
+			if symbol != tt.wantSymbol {
+				t.Errorf("symbol = %q, want %q", symbol, tt.wantSymbol)
+			}
+			if !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("violations = %+v, want %+v", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestNormalizeDelete(t *testing.T) {
+	u := NewPortfolioServiceUsecase(nil, fakeSymbolRegistry{"BTC": {Ticker: "BTC", Precision: 8}}, nil, nil)
+
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{symbol: "btc", want: "BTC"},
+		{symbol: "BTC", want: "BTC"},
+		// Позиции, записанные до реестра, удаляются под исходным символом
+		{symbol: "legacy", want: "legacy"},
+	}
+
+	for _, tt := range tests {
+		if got := u.normalizeDelete(tt.symbol); got != tt.want {
+			t.Errorf("normalizeDelete(%q) = %q, want %q", tt.symbol, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package portfolio
+
+import (
+	"context"
+	"crypto_analyzer-api_gateway/internal/domain/portfolio"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultTransactionPageSize = 50
+	MaxTransactionPageSize     = 200
+
+	// Допустимое расхождение часов клиента при записи сделки «на сейчас»
+	transactionClockSkew = 5 * time.Minute
+)
+
+// AddTransaction записывает сделку под каноническим тикером. Продажа и вывод не могут
+// превышать текущее количество актива — иначе *portfolio.InsufficientHoldingError.
+// Остаток известен только на текущий момент, поэтому продажа и вывод не могут датироваться
+// раньше последней сделки по активу: иначе журнал мог бы уйти в минус в прошлом.
+// Проверка не атомарна с записью: Portfolio Service должен повторять её у себя.
+func (u PortfolioUsecase) AddTransaction(ctx context.Context, tx portfolio.Transaction) (portfolio.Transaction, error) {
+	var violations []portfolio.AssetViolation
+
+	if tx.Reduces() {
+		tx.Symbol = u.normalizeDelete(tx.Symbol)
+	} else {
+		tx.Symbol, violations = u.normalizeWrite(0, tx.Symbol, tx.Amount)
+	}
+
+	if (tx.Type == portfolio.TransactionBuy || tx.Type == portfolio.TransactionSell) && tx.Price <= 0 {
+		violations = append(violations, portfolio.AssetViolation{Field: "price", Message: "price is required for buy and sell"})
+	}
+
+	now := time.Now().UTC()
+	if tx.ExecutedAt.IsZero() {
+		tx.ExecutedAt = now
+	} else if tx.ExecutedAt.After(now.Add(transactionClockSkew)) {
+		violations = append(violations, portfolio.AssetViolation{Field: "executed_at", Message: "executed_at is in the future"})
+	}
+
+	if len(violations) > 0 {
+		return portfolio.Transaction{}, &portfolio.AssetValidationError{Violations: violations}
+	}
+
+	if tx.Reduces() {
+		latest, err := u.portfolioService.ListTransactions(ctx, portfolio.TransactionQuery{
+			PortfolioId: tx.PortfolioId,
+			Symbol:      tx.Symbol,
+			PageSize:    1,
+		})
+		if err != nil {
+			return portfolio.Transaction{}, err
+		}
+
+		// Сделки отдаются от новых к старым
+		if len(latest.Transactions) > 0 && tx.ExecutedAt.Before(latest.Transactions[0].ExecutedAt) {
+			return portfolio.Transaction{}, &portfolio.AssetValidationError{Violations: []portfolio.AssetViolation{{
+				Field: "executed_at",
+				Message: fmt.Sprintf("%s must not be dated before the latest %s transaction at %s",
+					tx.Type, tx.Symbol, latest.Transactions[0].ExecutedAt.Format(time.RFC3339)),
+			}}}
+		}
+
+		content, err := u.portfolioService.GetPortfolioContentById(ctx, tx.PortfolioId)
+		if err != nil {
+			return portfolio.Transaction{}, err
+		}
+
+		if available := content.Assets[tx.Symbol]; tx.Amount > available {
+			return portfolio.Transaction{}, &portfolio.InsufficientHoldingError{
+				Symbol:    tx.Symbol,
+				Available: available,
+				Requested: tx.Amount,
+			}
+		}
+	}
+
+	return u.portfolioService.AddTransaction(ctx, tx)
+}
+
+func (u PortfolioUsecase) ListTransactions(ctx context.Context,
+	query portfolio.TransactionQuery) (portfolio.TransactionPage, error) {
+	if query.PageSize <= 0 {
+		query.PageSize = DefaultTransactionPageSize
+	}
+	query.PageSize = min(query.PageSize, MaxTransactionPageSize)
+
+	if query.Symbol != "" {
+		query.Symbol = u.normalizeDelete(query.Symbol)
+	}
+
+	return u.portfolioService.ListTransactions(ctx, query)
+}
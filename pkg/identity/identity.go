@@ -0,0 +1,169 @@
+// Package identity передаёт личность пользователя от API Gateway в backend-сервисы.
+//
+// Gateway подписывает короткоживущее утверждение (HMAC-SHA256) и кладёт его в gRPC metadata
+// под ключом MetadataKey. Сервисы проверяют его через Verifier или UnaryServerInterceptor
+// и не доверяют другим полям metadata (например, user_id).
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MetadataKey — ключ gRPC metadata с подписанным утверждением.
+const MetadataKey = "x-identity-assertion"
+
+// MinKeySize — минимальная длина ключа HMAC в байтах.
+const MinKeySize = 32
+
+var (
+	ErrMalformed        = errors.New("malformed identity assertion")
+	ErrUnknownKey       = errors.New("unknown identity signing key")
+	ErrInvalidSignature = errors.New("invalid identity signature")
+	ErrExpired          = errors.New("identity assertion expired")
+	ErrKeyTooShort      = fmt.Errorf("identity signing key must be at least %d bytes", MinKeySize)
+)
+
+// Identity — пользователь, от имени которого gateway выполняет запрос.
+type Identity struct {
+	UserID   string   `json:"uid"`
+	Username string   `json:"usr,omitempty"`
+	Roles    []string `json:"rol,omitempty"`
+	Scopes   []string `json:"scp,omitempty"`
+	// PortfolioIDs ограничивает доступ (API-ключи); пусто — без ограничений
+	PortfolioIDs []int32 `json:"pid,omitempty"`
+	RequestID    string  `json:"rid,omitempty"`
+	// ActorID — администратор, действующий от имени UserID (impersonation); пусто — сам пользователь
+	ActorID   string `json:"act,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Signer подписывает утверждения ключом keyID.
+type Signer struct {
+	keyID string
+	key   []byte
+	ttl   time.Duration
+}
+
+func NewSigner(keyID string, key []byte, ttl time.Duration) (*Signer, error) {
+	if len(key) < MinKeySize {
+		return nil, ErrKeyTooShort
+	}
+	if keyID == "" || strings.Contains(keyID, ".") {
+		return nil, errors.New("identity key id must be non-empty and must not contain dots")
+	}
+
+	return &Signer{keyID: keyID, key: key, ttl: ttl}, nil
+}
+
+// Sign выставляет iat/exp и возвращает утверждение вида <kid>.<payload>.<signature>.
+func (s *Signer) Sign(id Identity, now time.Time) (string, error) {
+	id.IssuedAt = now.Unix()
+	id.ExpiresAt = now.Add(s.ttl).Unix()
+
+	payload, err := json.Marshal(id)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal identity: %w", err)
+	}
+
+	signingInput := s.keyID + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign(s.key, signingInput)), nil
+}
+
+// Verifier проверяет утверждения. Несколько ключей позволяют ротацию без простоя.
+type Verifier struct {
+	keys   map[string][]byte
+	leeway time.Duration
+}
+
+// NewVerifier принимает ключи по keyID; leeway компенсирует расхождение часов.
+func NewVerifier(keys map[string][]byte, leeway time.Duration) (*Verifier, error) {
+	for kid, key := range keys {
+		if len(key) < MinKeySize {
+			return nil, fmt.Errorf("key %q: %w", kid, ErrKeyTooShort)
+		}
+	}
+
+	return &Verifier{keys: keys, leeway: leeway}, nil
+}
+
+func (v *Verifier) Verify(assertion string, now time.Time) (Identity, error) {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return Identity{}, ErrMalformed
+	}
+
+	key, ok := v.keys[parts[0]]
+	if !ok {
+		return Identity{}, ErrUnknownKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Identity{}, ErrMalformed
+	}
+
+	if !hmac.Equal(signature, sign(key, parts[0]+"."+parts[1])) {
+		return Identity{}, ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Identity{}, ErrMalformed
+	}
+
+	var id Identity
+	if err := json.Unmarshal(payload, &id); err != nil {
+		return Identity{}, ErrMalformed
+	}
+
+	if id.UserID == "" {
+		return Identity{}, ErrMalformed
+	}
+
+	if now.After(time.Unix(id.ExpiresAt, 0).Add(v.leeway)) {
+		return Identity{}, ErrExpired
+	}
+
+	return id, nil
+}
+
+func sign(key []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+type ctxIdentityKey struct{}
+
+type ctxRequestIDKey struct{}
+
+// NewContext сохраняет личность пользователя в ctx.
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, ctxIdentityKey{}, id)
+}
+
+// FromContext возвращает личность, сохранённую NewContext или серверным interceptor-ом.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(ctxIdentityKey{}).(Identity)
+	return id, ok
+}
+
+// WithRequestID сохраняет идентификатор запроса, он попадёт в утверждение.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, ctxRequestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(ctxRequestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,201 @@
+package identity
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testKey      = []byte(strings.Repeat("k", MinKeySize))
+	testOtherKey = []byte(strings.Repeat("o", MinKeySize))
+	testNow      = time.Unix(1_700_000_000, 0)
+)
+
+func testAssertion(t *testing.T, keyID string, key []byte, id Identity) string {
+	t.Helper()
+
+	signer, err := NewSigner(keyID, key, time.Minute)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+
+	assertion, err := signer.Sign(id, testNow)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	return assertion
+}
+
+// replacePart подменяет часть утверждения <kid>.<payload>.<signature>, не трогая подпись
+func replacePart(assertion string, i int, value string) string {
+	parts := strings.Split(assertion, ".")
+	parts[i] = value
+	return strings.Join(parts, ".")
+}
+
+func TestVerify(t *testing.T) {
+	verifier, err := NewVerifier(map[string][]byte{"k1": testKey, "k2": testOtherKey}, 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+
+	valid := testAssertion(t, "k1", testKey, Identity{UserID: "42", Username: "alice", Roles: []string{"user"}})
+	forgedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"uid":"1","rol":["admin"],"iat":0,"exp":9999999999}`))
+
+	tests := []struct {
+		name      string
+		assertion string
+		now       time.Time
+		wantErr   error
+		wantUser  string
+	}{
+		{
+			name:      "valid",
+			assertion: valid,
+			now:       testNow,
+			wantUser:  "42",
+		},
+		{
+			name:      "rotated key",
+			assertion: testAssertion(t, "k2", testOtherKey, Identity{UserID: "7"}),
+			now:       testNow,
+			wantUser:  "7",
+		},
+		{
+			name:      "within leeway",
+			assertion: valid,
+			now:       testNow.Add(time.Minute + 5*time.Second),
+			wantUser:  "42",
+		},
+		{
+			name:      "expired",
+			assertion: valid,
+			now:       testNow.Add(time.Minute + 6*time.Second),
+			wantErr:   ErrExpired,
+		},
+		{
+			name:      "tampered payload",
+			assertion: replacePart(valid, 1, forgedPayload),
+			now:       testNow,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "tampered signature",
+			assertion: replacePart(valid, 2, base64.RawURLEncoding.EncodeToString([]byte("signature"))),
+			now:       testNow,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "key id swapped",
+			assertion: replacePart(valid, 0, "k2"),
+			now:       testNow,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signed with unknown key",
+			assertion: testAssertion(t, "k1", []byte(strings.Repeat("x", MinKeySize)), Identity{UserID: "42"}),
+			now:       testNow,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "unknown key id",
+			assertion: replacePart(valid, 0, "k3"),
+			now:       testNow,
+			wantErr:   ErrUnknownKey,
+		},
+		{
+			name:      "missing user id",
+			assertion: testAssertion(t, "k1", testKey, Identity{Username: "alice"}),
+			now:       testNow,
+			wantErr:   ErrMalformed,
+		},
+		{
+			name:      "signature not base64",
+			assertion: replacePart(valid, 2, "!!!"),
+			now:       testNow,
+			wantErr:   ErrMalformed,
+		},
+		{
+			name:      "wrong number of parts",
+			assertion: "k1.payload",
+			now:       testNow,
+			wantErr:   ErrMalformed,
+		},
+		{
+			name:      "empty",
+			assertion: "",
+			now:       testNow,
+			wantErr:   ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := verifier.Verify(tt.assertion, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if id.UserID != tt.wantUser {
+				t.Errorf("Verify() user = %q, want %q", id.UserID, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestSignRoundTrip(t *testing.T) {
+	verifier, err := NewVerifier(map[string][]byte{"k1": testKey}, 0)
+	if err != nil {
+		t.Fatalf("NewVerifier: %v", err)
+	}
+
+	want := Identity{
+		UserID:       "42",
+		Username:     "alice",
+		Roles:        []string{"admin"},
+		Scopes:       []string{"portfolio:read"},
+		PortfolioIDs: []int32{1, 2},
+		RequestID:    "req-1",
+		ActorID:      "1",
+	}
+
+	got, err := verifier.Verify(testAssertion(t, "k1", testKey, want), testNow)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if got.IssuedAt != testNow.Unix() || got.ExpiresAt != testNow.Add(time.Minute).Unix() {
+		t.Errorf("iat/exp = %d/%d, want %d/%d", got.IssuedAt, got.ExpiresAt, testNow.Unix(), testNow.Add(time.Minute).Unix())
+	}
+	if got.UserID != want.UserID || got.Username != want.Username || got.ActorID != want.ActorID ||
+		got.RequestID != want.RequestID || len(got.PortfolioIDs) != 2 || got.Roles[0] != "admin" ||
+		got.Scopes[0] != "portfolio:read" {
+		t.Errorf("Verify() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyID   string
+		key     []byte
+		wantErr bool
+	}{
+		{name: "valid", keyID: "k1", key: testKey},
+		{name: "short key", keyID: "k1", key: testKey[:MinKeySize-1], wantErr: true},
+		{name: "empty key id", keyID: "", key: testKey, wantErr: true},
+		{name: "dot in key id", keyID: "k.1", key: testKey, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSigner(tt.keyID, tt.key, time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSigner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package identity
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// outgoing подписывает личность из ctx и добавляет её в исходящую metadata.
+// Без личности в ctx (анонимный запрос) утверждение не отправляется.
+func outgoing(ctx context.Context, signer *Signer) (context.Context, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+
+	if id.RequestID == "" {
+		id.RequestID = RequestIDFromContext(ctx)
+	}
+
+	assertion, err := signer.Sign(id, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign identity: %v", err)
+	}
+
+	// Set, а не Append: значение из ctx вызывающего не должно подменить подпись gateway
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(MetadataKey, assertion)
+
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
+func UnaryClientInterceptor(signer *Signer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := outgoing(ctx, signer)
+		if err != nil {
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func StreamClientInterceptor(signer *Signer) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := outgoing(ctx, signer)
+		if err != nil {
+			return nil, err
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// incoming проверяет утверждение и кладёт личность в ctx.
+// Запрос без утверждения пропускается анонимным: обязательность решает обработчик через Require.
+func incoming(ctx context.Context, verifier *Verifier) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	if len(values) > 1 {
+		return nil, status.Error(codes.Unauthenticated, "multiple identity assertions")
+	}
+
+	id, err := verifier.Verify(values[0], time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid identity: %v", err)
+	}
+
+	ctx = NewContext(ctx, id)
+	if id.RequestID != "" {
+		ctx = WithRequestID(ctx, id.RequestID)
+	}
+
+	return ctx, nil
+}
+
+func UnaryServerInterceptor(verifier *Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := incoming(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func StreamServerInterceptor(verifier *Verifier) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := incoming(ss.Context(), verifier)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// Require возвращает личность из ctx или ошибку Unauthenticated для обработчиков,
+// которым нужен аутентифицированный пользователь.
+func Require(ctx context.Context) (Identity, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return Identity{}, status.Error(codes.Unauthenticated, "identity assertion is required")
+	}
+
+	return id, nil
+}